@@ -122,7 +122,7 @@ func parseAndPushMetrics(client *remote.Client, data []byte, labels map[string]s
 		return false
 	}
 
-	_, err = client.Store(context.Background(), compressed, 0)
+	_, err = client.Store(context.Background(), compressed, 0, compression.Snappy)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "  FAILED:", err)
 		return false
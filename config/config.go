@@ -1357,6 +1357,12 @@ var (
 	RemoteWriteProtoMsgV2 RemoteWriteProtoMsg = "io.prometheus.write.v2.Request"
 )
 
+// Compressions accepted in remote write requests.
+const (
+	SnappyCompression = "snappy"
+	ZstdCompression   = "zstd"
+)
+
 // RemoteWriteConfig is the configuration for writing to remote storage.
 type RemoteWriteConfig struct {
 	URL                  *config.URL       `yaml:"url"`
@@ -1370,6 +1376,10 @@ type RemoteWriteConfig struct {
 	// ProtobufMessage specifies the protobuf message to use against the remote
 	// receiver as specified in https://prometheus.io/docs/specs/remote_write_spec_2_0/
 	ProtobufMessage RemoteWriteProtoMsg `yaml:"protobuf_message,omitempty"`
+	// Compression to use for write requests: snappy (default) or zstd. The
+	// sender falls back to snappy when the receiver rejects the configured
+	// compression.
+	Compression string `yaml:"compression,omitempty"`
 
 	// We cannot do proper Go type embedding below as the parser will then parse
 	// values arbitrarily into the overflow maps of further-down types.
@@ -1409,6 +1419,18 @@ func (c *RemoteWriteConfig) UnmarshalYAML(unmarshal func(any) error) error {
 		return fmt.Errorf("invalid protobuf_message value: %w", err)
 	}
 
+	switch c.Compression {
+	case "", SnappyCompression, ZstdCompression:
+	default:
+		return fmt.Errorf("invalid compression value %q, must be %q or %q", c.Compression, SnappyCompression, ZstdCompression)
+	}
+
+	switch c.QueueConfig.NonRetryableErrorPolicy {
+	case "", NonRetryableErrorPolicyDrop, NonRetryableErrorPolicyDeadLetter, NonRetryableErrorPolicyBlock:
+	default:
+		return fmt.Errorf("invalid non_retryable_error_policy value %q, must be %q, %q or %q", c.QueueConfig.NonRetryableErrorPolicy, NonRetryableErrorPolicyDrop, NonRetryableErrorPolicyDeadLetter, NonRetryableErrorPolicyBlock)
+	}
+
 	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
 	// We cannot make it a pointer as the parser panics for inlined pointer structs.
 	// Thus we just do its validation here.
@@ -1506,8 +1528,25 @@ type QueueConfig struct {
 
 	// Samples older than the limit will be dropped.
 	SampleAgeLimit model.Duration `yaml:"sample_age_limit,omitempty"`
+
+	// How to handle non-retryable errors from the remote endpoint; see the
+	// NonRetryableErrorPolicy constants. Defaults to dropping the data.
+	NonRetryableErrorPolicy string `yaml:"non_retryable_error_policy,omitempty"`
 }
 
+// Policies for handling non-retryable remote write errors.
+const (
+	// NonRetryableErrorPolicyDrop counts the rejected data in the failed
+	// metrics and drops it. This is the default.
+	NonRetryableErrorPolicyDrop = "drop"
+	// NonRetryableErrorPolicyDeadLetter additionally spills the rejected
+	// write request to a local dead letter file before dropping it.
+	NonRetryableErrorPolicyDeadLetter = "dead-letter"
+	// NonRetryableErrorPolicyBlock keeps retrying with backoff, trading
+	// progress (and potentially WAL disk space) for durability.
+	NonRetryableErrorPolicyBlock = "block"
+)
+
 // MetadataConfig is the configuration for sending metadata to remote
 // storage.
 type MetadataConfig struct {
@@ -1544,6 +1583,18 @@ type RemoteReadConfig struct {
 
 	// Whether to use the external labels as selectors for the remote read endpoint.
 	FilterExternalLabels bool `yaml:"filter_external_labels,omitempty"`
+
+	// FailoverGroup groups this endpoint with all other remote read endpoints
+	// that carry the same group name into a failover set. Endpoints of a set
+	// are assumed to serve the same data; queries try them in configuration
+	// order and only one endpoint's response is used. Query-time options
+	// (read_recent, required_matchers, filter_external_labels) are taken from
+	// the first endpoint of the set.
+	FailoverGroup string `yaml:"failover_group,omitempty"`
+	// HedgeDelay makes queries against a failover set start the next endpoint
+	// whenever the running attempts have not responded within this delay,
+	// instead of waiting for them to fail. Requires failover_group.
+	HedgeDelay model.Duration `yaml:"hedge_delay,omitempty"`
 }
 
 // SetDirectory joins any relative file paths with dir.
@@ -1564,6 +1615,9 @@ func (c *RemoteReadConfig) UnmarshalYAML(unmarshal func(any) error) error {
 	if err := validateHeaders(c.Headers); err != nil {
 		return err
 	}
+	if c.HedgeDelay != 0 && c.FailoverGroup == "" {
+		return errors.New("hedge_delay for remote_read requires failover_group to be set")
+	}
 	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
 	// We cannot make it a pointer as the parser panics for inlined pointer structs.
 	// Thus we just do its validation here.
@@ -88,6 +88,12 @@ const (
 	globScrapeFailureLogFile  = "testdata/fail.log"
 )
 
+var deadLetterQueueConfig = func() QueueConfig {
+	c := DefaultQueueConfig
+	c.NonRetryableErrorPolicy = NonRetryableErrorPolicyDeadLetter
+	return c
+}()
+
 var expectedConf = &Config{
 	loaded: true,
 	GlobalConfig: GlobalConfig{
@@ -156,7 +162,7 @@ var expectedConf = &Config{
 			URL:             mustParseURL("http://remote2/push"),
 			ProtobufMessage: RemoteWriteProtoMsgV2,
 			RemoteTimeout:   model.Duration(30 * time.Second),
-			QueueConfig:     DefaultQueueConfig,
+			QueueConfig:     deadLetterQueueConfig,
 			MetadataConfig:  DefaultMetadataConfig,
 			Name:            "rw_tls",
 			HTTPClientConfig: config.HTTPClientConfig{
@@ -185,6 +191,8 @@ var expectedConf = &Config{
 			ChunkedReadLimit: DefaultChunkedReadLimit,
 			ReadRecent:       true,
 			Name:             "default",
+			FailoverGroup:    "main",
+			HedgeDelay:       model.Duration(250 * time.Millisecond),
 			HTTPClientConfig: config.HTTPClientConfig{
 				FollowRedirects: true,
 				EnableHTTP2:     false,
@@ -2306,6 +2314,14 @@ var expectedErrors = []struct {
 		filename: "remote_read_dup.bad.yml",
 		errMsg:   `found multiple remote read configs with job name "queue1"`,
 	},
+	{
+		filename: "remote_read_hedge_without_group.bad.yml",
+		errMsg:   `hedge_delay for remote_read requires failover_group to be set`,
+	},
+	{
+		filename: "remote_write_non_retryable_policy.bad.yml",
+		errMsg:   `invalid non_retryable_error_policy value "retry", must be "drop", "dead-letter" or "block"`,
+	},
 	{
 		filename: "ec2_filters_empty_values.bad.yml",
 		errMsg:   `EC2 SD configuration filter values cannot be empty`,
@@ -193,6 +193,8 @@ type query struct {
 	matrix Matrix
 	// Cancellation function for the query.
 	cancel func()
+	// Whether the query's start and end were aligned to the step.
+	stepAligned bool
 
 	// The engine against which the query is executed.
 	ng *Engine
@@ -243,9 +245,24 @@ func (q *query) Exec(ctx context.Context) *Result {
 
 	// Exec query.
 	res, warnings, err := q.ng.exec(ctx, q)
+	if q.stepAligned && err == nil {
+		warnings = warnings.Add(annotations.NewRangeQueryStepAlignedInfo())
+	}
 	return &Result{Err: err, Value: res, Warnings: warnings}
 }
 
+// alignToStep aligns t down to a multiple of step relative to the Unix epoch.
+func alignToStep(t time.Time, step time.Duration) time.Time {
+	ms, stepMs := t.UnixMilli(), step.Milliseconds()
+	// Floor the remainder so that pre-epoch timestamps, where Go's % is
+	// negative, still align downwards.
+	rem := ms % stepMs
+	if rem < 0 {
+		rem += stepMs
+	}
+	return time.UnixMilli(ms - rem).UTC()
+}
+
 // contextDone returns an error if the context was canceled or timed out.
 func contextDone(ctx context.Context, env string) error {
 	if err := ctx.Err(); err != nil {
@@ -323,6 +340,12 @@ type EngineOpts struct {
 	EnableDelayedNameRemoval bool
 	// EnableTypeAndUnitLabels will allow PromQL Engine to make decisions based on the type and unit labels.
 	EnableTypeAndUnitLabels bool
+
+	// EnableRangeStepAlignment aligns the start and end of range queries
+	// down to multiples of the step, so repeated queries over a moving
+	// window evaluate at stable timestamps. Queries whose range was
+	// adjusted carry an info annotation in the result.
+	EnableRangeStepAlignment bool
 }
 
 // Engine handles the lifetime of queries from beginning to end.
@@ -342,6 +365,7 @@ type Engine struct {
 	enablePerStepStats       bool
 	enableDelayedNameRemoval bool
 	enableTypeAndUnitLabels  bool
+	enableRangeStepAlignment bool
 }
 
 // NewEngine returns a new engine.
@@ -434,6 +458,7 @@ func NewEngine(opts EngineOpts) *Engine {
 		enablePerStepStats:       opts.EnablePerStepStats,
 		enableDelayedNameRemoval: opts.EnableDelayedNameRemoval,
 		enableTypeAndUnitLabels:  opts.EnableTypeAndUnitLabels,
+		enableRangeStepAlignment: opts.EnableRangeStepAlignment,
 	}
 }
 
@@ -497,7 +522,15 @@ func (ng *Engine) NewInstantQuery(ctx context.Context, q storage.Queryable, opts
 // NewRangeQuery returns an evaluation query for the given time range and with
 // the resolution set by the interval.
 func (ng *Engine) NewRangeQuery(ctx context.Context, q storage.Queryable, opts QueryOpts, qs string, start, end time.Time, interval time.Duration) (Query, error) {
+	var stepAligned bool
+	if ng.enableRangeStepAlignment && interval > 0 {
+		alignedStart := alignToStep(start, interval)
+		alignedEnd := alignToStep(end, interval)
+		stepAligned = !alignedStart.Equal(start) || !alignedEnd.Equal(end)
+		start, end = alignedStart, alignedEnd
+	}
 	pExpr, qry := ng.newQuery(q, qs, opts, start, end, interval)
+	qry.stepAligned = stepAligned
 	finishQueue, err := ng.queueActive(ctx, qry)
 	if err != nil {
 		return nil, err
@@ -1710,7 +1743,7 @@ func (ev *evaluator) eval(ctx context.Context, expr parser.Expr) (parser.Value,
 		return result, warnings
 
 	case *parser.Call:
-		call := FunctionCalls[e.Func.Name]
+		call := getFunctionCall(e.Func.Name)
 		if e.Func.Name == "timestamp" {
 			// Matrix evaluation always returns the evaluation time,
 			// so this function needs special handling when given
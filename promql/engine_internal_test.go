@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/promslog"
 	"github.com/stretchr/testify/require"
@@ -141,3 +142,20 @@ func TestVectorElemBinop_Histograms(t *testing.T) {
 		})
 	}
 }
+
+func TestAlignToStep(t *testing.T) {
+	step := 30 * time.Second
+	for _, tc := range []struct {
+		t, want time.Time
+	}{
+		{t: time.Unix(23, 0), want: time.Unix(0, 0)},
+		{t: time.Unix(127, 0), want: time.Unix(120, 0)},
+		{t: time.Unix(120, 0), want: time.Unix(120, 0)},
+		// Pre-epoch timestamps align down, not towards zero.
+		{t: time.Unix(-23, 0), want: time.Unix(-30, 0)},
+		{t: time.Unix(-30, 0), want: time.Unix(-30, 0)},
+		{t: time.Unix(-127, 0), want: time.Unix(-150, 0)},
+	} {
+		require.Equal(t, tc.want.UTC(), alignToStep(tc.t, step), "aligning %s", tc.t)
+	}
+}
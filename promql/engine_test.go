@@ -3100,6 +3100,45 @@ func TestPreprocessAndWrapWithStepInvariantExpr(t *testing.T) {
 	}
 }
 
+func TestRangeQueryStepAlignment(t *testing.T) {
+	storage := promqltest.LoadedStorage(t, `
+load 10s
+  metric 0+1x100
+`)
+	t.Cleanup(func() { storage.Close() })
+
+	opts := promql.EngineOpts{
+		MaxSamples:               10000,
+		Timeout:                  10 * time.Second,
+		EnableRangeStepAlignment: true,
+	}
+	engine := promqltest.NewTestEngineWithOpts(t, opts)
+	ctx := context.Background()
+
+	// An unaligned range gets aligned down to multiples of the step and
+	// the result carries an info annotation.
+	qry, err := engine.NewRangeQuery(ctx, storage, nil, "metric", time.Unix(23, 0), time.Unix(127, 0), 30*time.Second)
+	require.NoError(t, err)
+	res := qry.Exec(ctx)
+	require.NoError(t, res.Err)
+	require.Len(t, res.Warnings, 1)
+	require.ErrorIs(t, res.Warnings.AsErrors()[0], annotations.RangeQueryStepAlignedInfo)
+
+	m, err := res.Matrix()
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	for _, p := range m[0].Floats {
+		require.Zero(t, p.T%(30*time.Second).Milliseconds())
+	}
+
+	// An already aligned range is left alone.
+	qry, err = engine.NewRangeQuery(ctx, storage, nil, "metric", time.Unix(30, 0), time.Unix(120, 0), 30*time.Second)
+	require.NoError(t, err)
+	res = qry.Exec(ctx)
+	require.NoError(t, res.Err)
+	require.Empty(t, res.Warnings)
+}
+
 func TestEngineOptsValidation(t *testing.T) {
 	cases := []struct {
 		opts     promql.EngineOpts
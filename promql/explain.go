@@ -0,0 +1,98 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// SelectorPlan describes one storage selection a query will perform: the
+// selector as written and the time range the engine will actually request
+// after widening it for lookback, ranges, offsets and @ modifiers.
+type SelectorPlan struct {
+	// Selector is the vector selector as written in the query. A range,
+	// if any, is reported through Range.
+	Selector string
+	// Start and End are the widened select range in milliseconds.
+	Start, End int64
+	// Range is the range vector length in milliseconds, zero for instant
+	// selectors.
+	Range int64
+	// Step is the resolution the selector is evaluated at in milliseconds.
+	Step int64
+	// Func is the innermost function or aggregation enclosing the
+	// selector, if any.
+	Func string
+	// Grouping are the labels of an enclosing by/without aggregation.
+	Grouping []string
+	// By indicates whether Grouping is a by (true) or without grouping.
+	By bool
+}
+
+// ExplainQuery parses qs and returns the storage selections its evaluation
+// over [start, end] at the given interval would perform, mirroring exactly
+// how the engine widens selector ranges and computes pushdown hints. For an
+// instant query pass equal start and end and a zero interval. Post-execution
+// sample counts are available separately through Query.Stats with per-step
+// stats enabled.
+func (ng *Engine) ExplainQuery(qs string, start, end time.Time, interval time.Duration) ([]SelectorPlan, error) {
+	expr, err := parser.ParseExpr(qs)
+	if err != nil {
+		return nil, err
+	}
+	expr, err = PreprocessExpr(expr, start, end, interval)
+	if err != nil {
+		return nil, err
+	}
+	s := &parser.EvalStmt{
+		Expr:          expr,
+		Start:         start,
+		End:           end,
+		Interval:      interval,
+		LookbackDelta: ng.lookbackDelta,
+	}
+
+	var (
+		plans     []SelectorPlan
+		evalRange time.Duration
+	)
+	parser.Inspect(s.Expr, func(node parser.Node, path []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.VectorSelector:
+			selStart, selEnd := getTimeRangesForSelector(s, n, path, evalRange)
+			step := ng.getLastSubqueryInterval(path)
+			if step == 0 {
+				step = s.Interval
+			}
+			plan := SelectorPlan{
+				Selector: n.String(),
+				Start:    selStart,
+				End:      selEnd,
+				Range:    durationMilliseconds(evalRange),
+				Step:     durationMilliseconds(step),
+				Func:     extractFuncFromPath(path),
+			}
+			plan.By, plan.Grouping = extractGroupsFromPath(path)
+			plans = append(plans, plan)
+			evalRange = 0
+
+		case *parser.MatrixSelector:
+			evalRange = n.Range
+		}
+		return nil
+	})
+	return plans, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/promql/promqltest"
+)
+
+func TestExplainQuery(t *testing.T) {
+	engine := promqltest.NewTestEngine(t, false, 0, promqltest.DefaultMaxSamplesPerQuery)
+	ts := time.Unix(3600, 0)
+
+	t.Run("range selector under aggregation", func(t *testing.T) {
+		plans, err := engine.ExplainQuery(`sum by (job) (rate(metric[5m] offset 1m))`, ts, ts, 0)
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+
+		p := plans[0]
+		require.Equal(t, `metric offset 1m`, p.Selector)
+		// Widened by the 5m range (minus 1ms to exclude the lower
+		// boundary) and shifted by the 1m offset.
+		require.Equal(t, ts.UnixMilli()-(5*time.Minute).Milliseconds()+1-(1*time.Minute).Milliseconds(), p.Start)
+		require.Equal(t, ts.UnixMilli()-(1*time.Minute).Milliseconds(), p.End)
+		require.Equal(t, (5 * time.Minute).Milliseconds(), p.Range)
+		require.Equal(t, "rate", p.Func)
+		// Grouping hints are only extracted when the aggregation
+		// directly encloses the selector, as in populateSeries.
+		require.False(t, p.By)
+		require.Empty(t, p.Grouping)
+	})
+
+	t.Run("direct aggregation grouping", func(t *testing.T) {
+		plans, err := engine.ExplainQuery(`sum by (job) (metric)`, ts, ts, 0)
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+		require.Equal(t, "sum", plans[0].Func)
+		require.True(t, plans[0].By)
+		require.Equal(t, []string{"job"}, plans[0].Grouping)
+	})
+
+	t.Run("instant selector uses lookback delta", func(t *testing.T) {
+		plans, err := engine.ExplainQuery(`metric1 + metric2`, ts, ts.Add(time.Hour), 15*time.Second)
+		require.NoError(t, err)
+		require.Len(t, plans, 2)
+
+		for _, p := range plans {
+			require.Equal(t, ts.UnixMilli()-(5*time.Minute).Milliseconds()+1, p.Start)
+			require.Equal(t, ts.Add(time.Hour).UnixMilli(), p.End)
+			require.Zero(t, p.Range)
+			require.Equal(t, (15 * time.Second).Milliseconds(), p.Step)
+			require.Empty(t, p.Func)
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		_, err := engine.ExplainQuery(`sum(`, ts, ts, 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// customFunctionCalls holds the evaluators of functions added at runtime via
+// RegisterFunction. They are kept out of FunctionCalls so that the built-in
+// table is never written after init and lookups of registered functions can
+// be guarded by a lock.
+var (
+	customFunctionsMtx  sync.RWMutex
+	customFunctionCalls = map[string]FunctionCall{}
+
+	functionNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// RegisterFunction makes a custom function available to the PromQL parser and
+// engine: f declares the name and signature used for parsing and type
+// checking, call implements the evaluation. Registration is process-wide
+// since the parser resolves function names from a package-level table. It is
+// safe to call concurrently with query parsing and evaluation; queries parsed
+// before registration do not know the function.
+//
+// An error is returned if the name is invalid or clashes with a built-in or
+// previously registered function.
+func RegisterFunction(f *parser.Function, call FunctionCall) error {
+	if f == nil || !functionNameRe.MatchString(f.Name) {
+		return fmt.Errorf("promql: invalid function name %q", f.Name)
+	}
+	if call == nil {
+		return fmt.Errorf("promql: no evaluator provided for function %q", f.Name)
+	}
+
+	customFunctionsMtx.Lock()
+	defer customFunctionsMtx.Unlock()
+	if !parser.RegisterFunction(f) {
+		return fmt.Errorf("promql: function %q is already registered", f.Name)
+	}
+	customFunctionCalls[f.Name] = call
+	return nil
+}
+
+// UnregisterFunction removes a function previously added with
+// RegisterFunction. Built-in functions cannot be removed. It reports whether
+// the function was registered. It is safe to call concurrently with query
+// parsing and evaluation; a query evaluating the function while it is being
+// unregistered fails the same way as one using an unknown function.
+func UnregisterFunction(name string) bool {
+	customFunctionsMtx.Lock()
+	defer customFunctionsMtx.Unlock()
+	if !parser.UnregisterFunction(name) {
+		return false
+	}
+	delete(customFunctionCalls, name)
+	return true
+}
+
+// getFunctionCall returns the evaluator for the named function, consulting
+// the built-in table first and then the runtime-registered functions.
+func getFunctionCall(name string) FunctionCall {
+	if call, ok := FunctionCalls[name]; ok {
+		return call
+	}
+	customFunctionsMtx.RLock()
+	defer customFunctionsMtx.RUnlock()
+	return customFunctionCalls[name]
+}
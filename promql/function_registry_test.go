@@ -0,0 +1,138 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/promql/promqltest"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+func TestRegisterFunction(t *testing.T) {
+	double := func(vectorVals []promql.Vector, _ promql.Matrix, _ parser.Expressions, enh *promql.EvalNodeHelper) (promql.Vector, annotations.Annotations) {
+		for _, s := range vectorVals[0] {
+			enh.Out = append(enh.Out, promql.Sample{Metric: s.Metric, F: 2 * s.F, DropName: true})
+		}
+		return enh.Out, nil
+	}
+
+	require.NoError(t, promql.RegisterFunction(&parser.Function{
+		Name:       "double",
+		ArgTypes:   []parser.ValueType{parser.ValueTypeVector},
+		ReturnType: parser.ValueTypeVector,
+	}, double))
+	t.Cleanup(func() { require.True(t, promql.UnregisterFunction("double")) })
+
+	// Conflicts with built-ins and duplicate registrations are rejected.
+	require.Error(t, promql.RegisterFunction(&parser.Function{
+		Name:       "abs",
+		ArgTypes:   []parser.ValueType{parser.ValueTypeVector},
+		ReturnType: parser.ValueTypeVector,
+	}, double))
+	require.Error(t, promql.RegisterFunction(&parser.Function{
+		Name:       "double",
+		ArgTypes:   []parser.ValueType{parser.ValueTypeVector},
+		ReturnType: parser.ValueTypeVector,
+	}, double))
+	require.Error(t, promql.RegisterFunction(&parser.Function{Name: "not a name"}, double))
+	require.Error(t, promql.RegisterFunction(&parser.Function{Name: "nilcall"}, nil))
+
+	// The registered function parses and evaluates like a built-in.
+	st := promqltest.LoadedStorage(t, `
+load 10s
+  metric{job="a"} 21
+`)
+	t.Cleanup(func() { st.Close() })
+	engine := promqltest.NewTestEngine(t, false, 0, promqltest.DefaultMaxSamplesPerQuery)
+
+	qry, err := engine.NewInstantQuery(context.Background(), st, nil, `double(metric)`, time.Unix(10, 0))
+	require.NoError(t, err)
+	res := qry.Exec(context.Background())
+	require.NoError(t, res.Err)
+
+	vec, err := res.Vector()
+	require.NoError(t, err)
+	require.Len(t, vec, 1)
+	require.Equal(t, 42.0, vec[0].F)
+
+	// Unregistered functions are unknown to the parser again.
+	require.True(t, promql.UnregisterFunction("double"))
+	require.False(t, promql.UnregisterFunction("double"))
+	require.False(t, promql.UnregisterFunction("abs"))
+	_, err = engine.NewInstantQuery(context.Background(), st, nil, `double(metric)`, time.Unix(10, 0))
+	require.Error(t, err)
+
+	require.NoError(t, promql.RegisterFunction(&parser.Function{
+		Name:       "double",
+		ArgTypes:   []parser.ValueType{parser.ValueTypeVector},
+		ReturnType: parser.ValueTypeVector,
+	}, double))
+}
+
+func TestRegisterFunctionConcurrentWithQueries(t *testing.T) {
+	identity := func(vectorVals []promql.Vector, _ promql.Matrix, _ parser.Expressions, enh *promql.EvalNodeHelper) (promql.Vector, annotations.Annotations) {
+		return append(enh.Out, vectorVals[0]...), nil
+	}
+
+	st := promqltest.LoadedStorage(t, `
+load 10s
+  metric{job="a"} 21
+`)
+	t.Cleanup(func() { st.Close() })
+	engine := promqltest.NewTestEngine(t, false, 0, promqltest.DefaultMaxSamplesPerQuery)
+
+	// Registration must be safe while other goroutines parse and evaluate
+	// queries; run both and let the race detector judge.
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			name := fmt.Sprintf("custom_fn_%d", i%3)
+			if promql.RegisterFunction(&parser.Function{
+				Name:       name,
+				ArgTypes:   []parser.ValueType{parser.ValueTypeVector},
+				ReturnType: parser.ValueTypeVector,
+			}, identity) == nil {
+				promql.UnregisterFunction(name)
+			}
+		}
+	}()
+
+	for range 100 {
+		qry, err := engine.NewInstantQuery(context.Background(), st, nil, `abs(metric)`, time.Unix(10, 0))
+		require.NoError(t, err)
+		res := qry.Exec(context.Background())
+		require.NoError(t, res.Err)
+		qry.Close()
+	}
+
+	close(stop)
+	wg.Wait()
+}
@@ -1736,13 +1736,27 @@ func (ev *evaluator) evalLabelJoin(ctx context.Context, args parser.Expressions)
 }
 
 // Common code for date related functions.
-func dateWrapper(vectorVals []Vector, enh *EvalNodeHelper, f func(time.Time) float64) Vector {
+func dateWrapper(vectorVals []Vector, args parser.Expressions, enh *EvalNodeHelper, f func(time.Time) float64) (Vector, annotations.Annotations) {
+	var annos annotations.Annotations
+	loc := time.UTC
+	if len(args) == 2 {
+		// The timezone literal is validated at parse time, but an AST that
+		// didn't go through that validation may carry an unknown timezone;
+		// compute in UTC then and tell the caller, rather than calling In
+		// with a nil location.
+		tz := stringFromArg(args[1])
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		} else {
+			annos.Add(annotations.NewInvalidTimezoneWarning(tz, args[1].PositionRange()))
+		}
+	}
 	if len(vectorVals) == 0 {
 		return append(enh.Out,
 			Sample{
 				Metric: labels.Labels{},
-				F:      f(time.Unix(enh.Ts/1000, 0).UTC()),
-			})
+				F:      f(time.Unix(enh.Ts/1000, 0).In(loc)),
+			}), annos
 	}
 
 	for _, el := range vectorVals[0] {
@@ -1750,7 +1764,7 @@ func dateWrapper(vectorVals []Vector, enh *EvalNodeHelper, f func(time.Time) flo
 			// Ignore histogram sample.
 			continue
 		}
-		t := time.Unix(int64(el.F), 0).UTC()
+		t := time.Unix(int64(el.F), 0).In(loc)
 		if !enh.enableDelayedNameRemoval {
 			el.Metric = el.Metric.DropReserved(schema.IsMetadataLabel)
 		}
@@ -1760,63 +1774,63 @@ func dateWrapper(vectorVals []Vector, enh *EvalNodeHelper, f func(time.Time) flo
 			DropName: true,
 		})
 	}
-	return enh.Out
+	return enh.Out, annos
 }
 
 // === days_in_month(v Vector) Scalar ===
-func funcDaysInMonth(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcDaysInMonth(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(32 - time.Date(t.Year(), t.Month(), 32, 0, 0, 0, 0, time.UTC).Day())
-	}), nil
+	})
 }
 
 // === day_of_month(v Vector) Scalar ===
-func funcDayOfMonth(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcDayOfMonth(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(t.Day())
-	}), nil
+	})
 }
 
 // === day_of_week(v Vector) Scalar ===
-func funcDayOfWeek(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcDayOfWeek(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(t.Weekday())
-	}), nil
+	})
 }
 
 // === day_of_year(v Vector) Scalar ===
-func funcDayOfYear(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcDayOfYear(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(t.YearDay())
-	}), nil
+	})
 }
 
 // === hour(v Vector) Scalar ===
-func funcHour(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcHour(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(t.Hour())
-	}), nil
+	})
 }
 
 // === minute(v Vector) Scalar ===
-func funcMinute(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcMinute(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(t.Minute())
-	}), nil
+	})
 }
 
 // === month(v Vector) Scalar ===
-func funcMonth(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcMonth(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(t.Month())
-	}), nil
+	})
 }
 
 // === year(v Vector) Scalar ===
-func funcYear(vectorVals []Vector, _ Matrix, _ parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
-	return dateWrapper(vectorVals, enh, func(t time.Time) float64 {
+func funcYear(vectorVals []Vector, _ Matrix, args parser.Expressions, enh *EvalNodeHelper) (Vector, annotations.Annotations) {
+	return dateWrapper(vectorVals, args, enh, func(t time.Time) float64 {
 		return float64(t.Year())
-	}), nil
+	})
 }
 
 // FunctionCalls is a list of all functions supported by PromQL, including their types.
@@ -13,6 +13,8 @@
 
 package parser
 
+import "sync"
+
 // Function represents a function of the expression language and is
 // used by function nodes.
 type Function struct {
@@ -120,25 +122,25 @@ var Functions = map[string]*Function{
 	},
 	"days_in_month": {
 		Name:       "days_in_month",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
 	"day_of_month": {
 		Name:       "day_of_month",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
 	"day_of_week": {
 		Name:       "day_of_week",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
 	"day_of_year": {
 		Name:       "day_of_year",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
@@ -216,7 +218,7 @@ var Functions = map[string]*Function{
 	},
 	"hour": {
 		Name:       "hour",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
@@ -315,13 +317,13 @@ var Functions = map[string]*Function{
 	},
 	"minute": {
 		Name:       "minute",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
 	"month": {
 		Name:       "month",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
@@ -457,14 +459,73 @@ var Functions = map[string]*Function{
 	},
 	"year": {
 		Name:       "year",
-		ArgTypes:   []ValueType{ValueTypeVector},
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
 		Variadic:   1,
 		ReturnType: ValueTypeVector,
 	},
 }
 
-// getFunction returns a predefined Function object for the given name.
+// calendarFunctions accept an optional trailing IANA timezone argument and,
+// unlike other variadic functions, may also be called with no arguments at
+// all, defaulting to the evaluation timestamp.
+var calendarFunctions = map[string]struct{}{
+	"days_in_month": {},
+	"day_of_month":  {},
+	"day_of_week":   {},
+	"day_of_year":   {},
+	"hour":          {},
+	"minute":        {},
+	"month":         {},
+	"year":          {},
+}
+
+// registeredFunctions holds functions added at runtime via RegisterFunction.
+// They are kept out of Functions so that the built-in table is never written
+// after init and lookups of registered functions can be guarded by a lock.
+var (
+	registeredFunctionsMtx sync.RWMutex
+	registeredFunctions    = map[string]*Function{}
+)
+
+// RegisterFunction makes f resolvable by the parser. It reports whether the
+// name was free; built-in and previously registered names are not overwritten.
+// It is safe to call concurrently with parsing. Most callers should use
+// promql.RegisterFunction instead, which also installs the evaluator.
+func RegisterFunction(f *Function) bool {
+	registeredFunctionsMtx.Lock()
+	defer registeredFunctionsMtx.Unlock()
+	if _, ok := Functions[f.Name]; ok {
+		return false
+	}
+	if _, ok := registeredFunctions[f.Name]; ok {
+		return false
+	}
+	registeredFunctions[f.Name] = f
+	return true
+}
+
+// UnregisterFunction removes a function previously added with
+// RegisterFunction and reports whether it was registered. Built-in functions
+// cannot be removed. It is safe to call concurrently with parsing; queries
+// already parsed keep their resolved *Function.
+func UnregisterFunction(name string) bool {
+	registeredFunctionsMtx.Lock()
+	defer registeredFunctionsMtx.Unlock()
+	if _, ok := registeredFunctions[name]; !ok {
+		return false
+	}
+	delete(registeredFunctions, name)
+	return true
+}
+
+// getFunction returns a predefined Function object for the given name,
+// falling back to the runtime-registered functions.
 func getFunction(name string, functions map[string]*Function) (*Function, bool) {
-	function, ok := functions[name]
+	if function, ok := functions[name]; ok {
+		return function, true
+	}
+	registeredFunctionsMtx.RLock()
+	defer registeredFunctionsMtx.RUnlock()
+	function, ok := registeredFunctions[name]
 	return function, ok
 }
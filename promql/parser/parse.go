@@ -24,6 +24,11 @@ import (
 	"sync"
 	"time"
 
+	// Embed the timezone database so that the timezone argument of the
+	// calendar functions resolves the same way on every host, regardless of
+	// whether system zoneinfo is installed.
+	_ "time/tzdata"
+
 	"github.com/prometheus/common/model"
 
 	"github.com/prometheus/prometheus/model/histogram"
@@ -786,12 +791,22 @@ func (p *parser) checkAST(node Node) (typ ValueType) {
 				p.addParseErrf(n.PositionRange(), "expected %d argument(s) in call to %q, got %d", nargs, n.Func.Name, len(n.Args))
 			}
 		} else {
+			_, isCalendar := calendarFunctions[n.Func.Name]
 			na := nargs - 1
-			if na > len(n.Args) {
+			// The calendar functions may additionally be called with no
+			// arguments at all, defaulting to the evaluation timestamp.
+			if na > len(n.Args) && !(isCalendar && len(n.Args) == 0) {
 				p.addParseErrf(n.PositionRange(), "expected at least %d argument(s) in call to %q, got %d", na, n.Func.Name, len(n.Args))
 			} else if nargsmax := na + n.Func.Variadic; n.Func.Variadic > 0 && nargsmax < len(n.Args) {
 				p.addParseErrf(n.PositionRange(), "expected at most %d argument(s) in call to %q, got %d", nargsmax, n.Func.Name, len(n.Args))
 			}
+			if isCalendar && len(n.Args) == 2 {
+				if sl, ok := n.Args[1].(*StringLiteral); ok {
+					if _, err := time.LoadLocation(sl.Val); err != nil {
+						p.addParseErrf(n.Args[1].PositionRange(), "invalid timezone in call to %q: %s", n.Func.Name, err)
+					}
+				}
+			}
 		}
 
 		if n.Func.Name == "info" && len(n.Args) > 1 {
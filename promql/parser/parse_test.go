@@ -3303,9 +3303,30 @@ var testExpr = []struct {
 		errors: ParseErrors{
 			ParseErr{
 				PositionRange: posrange.PositionRange{Start: 0, End: 43},
-				Err:           errors.New("expected at most 1 argument(s) in call to \"hour\", got 3"),
+				Err:           errors.New("expected at most 2 argument(s) in call to \"hour\", got 3"),
 				Query:         "hour(some_metric, some_metric, some_metric)",
 			},
+			ParseErr{
+				PositionRange: posrange.PositionRange{Start: 18, End: 29},
+				Err:           errors.New("expected type string in call to function \"hour\", got instant vector"),
+				Query:         "hour(some_metric, some_metric, some_metric)",
+			},
+			ParseErr{
+				PositionRange: posrange.PositionRange{Start: 31, End: 42},
+				Err:           errors.New("expected type string in call to function \"hour\", got instant vector"),
+				Query:         "hour(some_metric, some_metric, some_metric)",
+			},
+		},
+	},
+	{
+		input: `hour(some_metric, "Not/AZone")`,
+		fail:  true,
+		errors: ParseErrors{
+			ParseErr{
+				PositionRange: posrange.PositionRange{Start: 18, End: 29},
+				Err:           errors.New(`invalid timezone in call to "hour": unknown time zone Not/AZone`),
+				Query:         `hour(some_metric, "Not/AZone")`,
+			},
 		},
 	},
 	{
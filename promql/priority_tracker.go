@@ -0,0 +1,182 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/util/gate"
+)
+
+// QueryPriority is the admission class of a query. Queries of different
+// priorities draw from separate concurrency pools, so a burst in one class
+// cannot starve the other.
+type QueryPriority int
+
+const (
+	// QueryPriorityLow is the default class, intended for ad-hoc and
+	// dashboard queries arriving through the API.
+	QueryPriorityLow QueryPriority = iota
+	// QueryPriorityHigh is intended for background work that must not be
+	// starved, such as rule evaluation.
+	QueryPriorityHigh
+)
+
+func (p QueryPriority) String() string {
+	if p == QueryPriorityHigh {
+		return "high"
+	}
+	return "low"
+}
+
+type queryPriorityKey struct{}
+
+// ContextWithQueryPriority returns a context carrying the given query
+// priority. It is consulted by PriorityQueryTracker on Insert.
+func ContextWithQueryPriority(ctx context.Context, p QueryPriority) context.Context {
+	return context.WithValue(ctx, queryPriorityKey{}, p)
+}
+
+// QueryPriorityFromContext returns the query priority stored in ctx, or
+// QueryPriorityLow if none was set.
+func QueryPriorityFromContext(ctx context.Context) QueryPriority {
+	if p, ok := ctx.Value(queryPriorityKey{}).(QueryPriority); ok {
+		return p
+	}
+	return QueryPriorityLow
+}
+
+// ErrQueueTimeout is returned by PriorityQueryTracker.Insert when a query
+// waited longer than the configured queue timeout for a concurrency slot.
+type ErrQueueTimeout string
+
+func (e ErrQueueTimeout) Error() string {
+	return fmt.Sprintf("query timed out waiting for a slot in the %s priority queue", string(e))
+}
+
+// PriorityQueryTracker is a QueryTracker that admits queries from separate
+// per-priority concurrency pools with an optional bound on queueing time.
+// The priority of a query is read from its context via
+// ContextWithQueryPriority and defaults to QueryPriorityLow.
+//
+// An inner tracker may be supplied to retain its bookkeeping, typically an
+// ActiveQueryTracker for the crash-persistent query log. The inner tracker's
+// maximum concurrency should be at least the sum of the pool sizes so that it
+// never blocks admission itself.
+type PriorityQueryTracker struct {
+	inner        QueryTracker
+	gates        map[QueryPriority]*gate.Gate
+	queueTimeout time.Duration
+
+	mtx        sync.Mutex
+	priorities map[int]QueryPriority
+	nextIndex  int
+}
+
+// NewPriorityQueryTracker returns a PriorityQueryTracker admitting up to
+// maxHigh high-priority and maxLow low-priority concurrent queries. A
+// queueTimeout of zero means queries wait for a slot until their own context
+// is done. inner may be nil.
+func NewPriorityQueryTracker(inner QueryTracker, maxHigh, maxLow int, queueTimeout time.Duration) *PriorityQueryTracker {
+	return &PriorityQueryTracker{
+		inner: inner,
+		gates: map[QueryPriority]*gate.Gate{
+			QueryPriorityHigh: gate.New(maxHigh),
+			QueryPriorityLow:  gate.New(maxLow),
+		},
+		queueTimeout: queueTimeout,
+		priorities:   map[int]QueryPriority{},
+	}
+}
+
+// GetMaxConcurrent implements QueryTracker.
+func (t *PriorityQueryTracker) GetMaxConcurrent() int {
+	total := 0
+	for _, g := range t.gates {
+		total += g.Capacity()
+	}
+	return total
+}
+
+// Insert implements QueryTracker.
+func (t *PriorityQueryTracker) Insert(ctx context.Context, query string) (int, error) {
+	priority := QueryPriorityFromContext(ctx)
+	if _, ok := t.gates[priority]; !ok {
+		// QueryPriority is an int, so the context may carry values beyond
+		// the defined constants; treat those as low priority.
+		priority = QueryPriorityLow
+	}
+
+	waitCtx := ctx
+	if t.queueTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, t.queueTimeout)
+		defer cancel()
+	}
+	if err := t.gates[priority].Start(waitCtx); err != nil {
+		// Distinguish our queue timeout from the query's own context
+		// being done.
+		if ctx.Err() == nil {
+			return 0, ErrQueueTimeout(priority.String())
+		}
+		return 0, err
+	}
+
+	idx, err := t.insertInner(ctx, query)
+	if err != nil {
+		t.gates[priority].Done()
+		return 0, err
+	}
+
+	t.mtx.Lock()
+	t.priorities[idx] = priority
+	t.mtx.Unlock()
+	return idx, nil
+}
+
+func (t *PriorityQueryTracker) insertInner(ctx context.Context, query string) (int, error) {
+	if t.inner != nil {
+		return t.inner.Insert(ctx, query)
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.nextIndex++
+	return t.nextIndex, nil
+}
+
+// Delete implements QueryTracker.
+func (t *PriorityQueryTracker) Delete(insertIndex int) {
+	t.mtx.Lock()
+	priority, ok := t.priorities[insertIndex]
+	delete(t.priorities, insertIndex)
+	t.mtx.Unlock()
+
+	if t.inner != nil {
+		t.inner.Delete(insertIndex)
+	}
+	if ok {
+		t.gates[priority].Done()
+	}
+}
+
+// Close implements QueryTracker.
+func (t *PriorityQueryTracker) Close() error {
+	if t.inner != nil {
+		return t.inner.Close()
+	}
+	return nil
+}
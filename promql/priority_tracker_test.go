@@ -0,0 +1,87 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityQueryTrackerIsolatesClasses(t *testing.T) {
+	tracker := NewPriorityQueryTracker(nil, 1, 1, 0)
+	require.Equal(t, 2, tracker.GetMaxConcurrent())
+
+	ctx := context.Background()
+
+	// Fill the low-priority pool.
+	lowIdx, err := tracker.Insert(ctx, "low")
+	require.NoError(t, err)
+
+	// High-priority queries still get admitted immediately.
+	highIdx, err := tracker.Insert(ContextWithQueryPriority(ctx, QueryPriorityHigh), "high")
+	require.NoError(t, err)
+	tracker.Delete(highIdx)
+
+	// A second low-priority query blocks until the first is done.
+	blockedCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = tracker.Insert(blockedCtx, "low2")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	tracker.Delete(lowIdx)
+	lowIdx2, err := tracker.Insert(ctx, "low3")
+	require.NoError(t, err)
+	tracker.Delete(lowIdx2)
+
+	// Priorities beyond the defined constants are treated as low priority
+	// rather than panicking on a missing gate.
+	oddIdx, err := tracker.Insert(ContextWithQueryPriority(ctx, QueryPriority(42)), "odd")
+	require.NoError(t, err)
+	blockedCtx2, cancel2 := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel2()
+	_, err = tracker.Insert(blockedCtx2, "low4")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	tracker.Delete(oddIdx)
+}
+
+func TestPriorityQueryTrackerQueueTimeout(t *testing.T) {
+	tracker := NewPriorityQueryTracker(nil, 1, 1, 10*time.Millisecond)
+	ctx := context.Background()
+
+	idx, err := tracker.Insert(ctx, "first")
+	require.NoError(t, err)
+
+	_, err = tracker.Insert(ctx, "second")
+	var queueErr ErrQueueTimeout
+	require.ErrorAs(t, err, &queueErr)
+	require.Equal(t, ErrQueueTimeout("low"), queueErr)
+
+	// The query's own context being done takes precedence over the queue
+	// timeout error.
+	doneCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = tracker.Insert(doneCtx, "canceled")
+	require.ErrorIs(t, err, context.Canceled)
+
+	tracker.Delete(idx)
+}
+
+func TestQueryPriorityFromContext(t *testing.T) {
+	require.Equal(t, QueryPriorityLow, QueryPriorityFromContext(context.Background()))
+	require.Equal(t, QueryPriorityHigh,
+		QueryPriorityFromContext(ContextWithQueryPriority(context.Background(), QueryPriorityHigh)))
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+// RangeResultCache stores evaluated range query results keyed by query,
+// step-aligned time range and evaluation options. Implementations own eviction
+// and size bounds; a cached Matrix and its annotations must be treated as
+// immutable by both sides.
+type RangeResultCache interface {
+	// Get returns the cached result and its annotations for key, if any.
+	Get(ctx context.Context, key string) (Matrix, annotations.Annotations, bool)
+	// Put stores the result and its annotations for key.
+	Put(ctx context.Context, key string, result Matrix, warnings annotations.Annotations)
+}
+
+// CachedRangeQuerier evaluates range queries through a RangeResultCache. The
+// query range is split into intervals aligned to multiples of the step, each
+// interval is served from the cache or evaluated and stored, and the pieces
+// are merged back into a single Matrix. Intervals overlapping the
+// still-appendable head window are always evaluated and never cached, since
+// their data may still change.
+type CachedRangeQuerier struct {
+	ng        *Engine
+	queryable storage.Queryable
+	cache     RangeResultCache
+
+	// splitInterval is the target width of one cacheable interval. It is
+	// rounded to a whole number of steps per query.
+	splitInterval time.Duration
+	// headWindow is how far back from now results are considered mutable.
+	headWindow time.Duration
+	now        func() time.Time
+}
+
+// NewCachedRangeQuerier returns a CachedRangeQuerier evaluating against q
+// using ng. splitInterval controls the granularity of cached intervals and
+// headWindow how far back from now results are still considered mutable.
+func NewCachedRangeQuerier(ng *Engine, q storage.Queryable, cache RangeResultCache, splitInterval, headWindow time.Duration) *CachedRangeQuerier {
+	return &CachedRangeQuerier{
+		ng:            ng,
+		queryable:     q,
+		cache:         cache,
+		splitInterval: splitInterval,
+		headWindow:    headWindow,
+		now:           time.Now,
+	}
+}
+
+// RangeQuery evaluates qs over [start, end] at the given interval, consulting
+// the cache for every step-aligned split of the range.
+func (c *CachedRangeQuerier) RangeQuery(ctx context.Context, opts QueryOpts, qs string, start, end time.Time, interval time.Duration) *Result {
+	if interval <= 0 {
+		return &Result{Err: fmt.Errorf("invalid interval %s for range query", interval)}
+	}
+	if end.Before(start) {
+		return &Result{Err: fmt.Errorf("invalid range query: end timestamp %s is before start timestamp %s", end.Format(time.RFC3339), start.Format(time.RFC3339))}
+	}
+
+	var (
+		merged   = map[string]*Series{}
+		order    []string
+		warnings annotations.Annotations
+		cutoff   = c.now().Add(-c.headWindow)
+	)
+	for _, r := range splitRangeByStep(start, end, interval, c.splitInterval) {
+		cacheable := r.end.Before(cutoff)
+		key := rangeResultCacheKey(qs, r.start, r.end, interval, c.effectiveLookbackDelta(opts))
+
+		var (
+			m   Matrix
+			hit bool
+		)
+		if cacheable {
+			var w annotations.Annotations
+			// A hit may legitimately carry an empty Matrix, so trust the
+			// bool rather than the result being non-nil.
+			if m, w, hit = c.cache.Get(ctx, key); hit {
+				warnings.Merge(w)
+			}
+		}
+		if !hit {
+			q, err := c.ng.NewRangeQuery(ctx, c.queryable, opts, qs, r.start, r.end, interval)
+			if err != nil {
+				return &Result{Err: err}
+			}
+			res := q.Exec(ctx)
+			if res.Err != nil {
+				q.Close()
+				return res
+			}
+			warnings.Merge(res.Warnings)
+			mat, err := res.Matrix()
+			if err != nil {
+				q.Close()
+				return &Result{Err: err}
+			}
+			// Copy before Close returns the result's point slices
+			// to the evaluator's pools.
+			m = copyMatrix(mat)
+			q.Close()
+			if cacheable {
+				c.cache.Put(ctx, key, m, res.Warnings)
+			}
+		}
+
+		for _, s := range m {
+			k := s.Metric.String()
+			ms, ok := merged[k]
+			if !ok {
+				ms = &Series{Metric: s.Metric, DropName: s.DropName}
+				merged[k] = ms
+				order = append(order, k)
+			}
+			// Splits are processed in time order, so appending keeps
+			// the points sorted.
+			ms.Floats = append(ms.Floats, s.Floats...)
+			ms.Histograms = append(ms.Histograms, s.Histograms...)
+		}
+	}
+
+	result := make(Matrix, 0, len(order))
+	for _, k := range order {
+		result = append(result, *merged[k])
+	}
+	sort.Sort(result)
+	return &Result{Value: result, Warnings: warnings}
+}
+
+// timeRange is one step-aligned split of a range query.
+type timeRange struct {
+	start, end time.Time
+}
+
+// splitRangeByStep splits [start, end] into ranges of at most splitInterval,
+// rounded to whole multiples of the step so that the evaluation timestamps of
+// the splits are exactly those of the full query.
+func splitRangeByStep(start, end time.Time, step, splitInterval time.Duration) []timeRange {
+	totalSteps := int64(end.Sub(start)/step) + 1
+	stepsPerSplit := int64(splitInterval / step)
+	if stepsPerSplit < 1 {
+		stepsPerSplit = 1
+	}
+
+	var ranges []timeRange
+	for first := int64(0); first < totalSteps; first += stepsPerSplit {
+		last := min(first+stepsPerSplit-1, totalSteps-1)
+		ranges = append(ranges, timeRange{
+			start: start.Add(time.Duration(first) * step),
+			end:   start.Add(time.Duration(last) * step),
+		})
+	}
+	return ranges
+}
+
+// effectiveLookbackDelta returns the lookback delta the engine will evaluate
+// with for the given options, falling back to the engine default like
+// Engine.NewRangeQuery does.
+func (c *CachedRangeQuerier) effectiveLookbackDelta(opts QueryOpts) time.Duration {
+	if opts != nil && opts.LookbackDelta() > 0 {
+		return opts.LookbackDelta()
+	}
+	return c.ng.lookbackDelta
+}
+
+func rangeResultCacheKey(qs string, start, end time.Time, interval, lookbackDelta time.Duration) string {
+	return fmt.Sprintf("%s\xff%d\xff%d\xff%d\xff%d", qs, start.UnixMilli(), end.UnixMilli(), interval.Milliseconds(), lookbackDelta.Milliseconds())
+}
+
+func copyMatrix(m Matrix) Matrix {
+	c := make(Matrix, len(m))
+	for i, s := range m {
+		c[i] = Series{
+			Metric:   s.Metric,
+			Floats:   append([]FPoint(nil), s.Floats...),
+			DropName: s.DropName,
+		}
+		if len(s.Histograms) > 0 {
+			c[i].Histograms = make([]HPoint, len(s.Histograms))
+			for j, h := range s.Histograms {
+				c[i].Histograms[j] = HPoint{T: h.T, H: h.H.Copy()}
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/promqltest"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+// mapResultCache is an unbounded RangeResultCache for tests.
+type mapResultCache struct {
+	entries      map[string]cacheEntry
+	hits, misses int
+}
+
+type cacheEntry struct {
+	m promql.Matrix
+	w annotations.Annotations
+}
+
+func newMapResultCache() *mapResultCache {
+	return &mapResultCache{entries: map[string]cacheEntry{}}
+}
+
+func (c *mapResultCache) Get(_ context.Context, key string) (promql.Matrix, annotations.Annotations, bool) {
+	e, ok := c.entries[key]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return e.m, e.w, ok
+}
+
+func (c *mapResultCache) Put(_ context.Context, key string, result promql.Matrix, warnings annotations.Annotations) {
+	c.entries[key] = cacheEntry{m: result, w: warnings}
+}
+
+func TestCachedRangeQuerier(t *testing.T) {
+	st := promqltest.LoadedStorage(t, `
+load 10s
+  metric{job="a"} 0+1x100
+  metric{job="b"} 0+2x100
+`)
+	t.Cleanup(func() { st.Close() })
+
+	engine := promqltest.NewTestEngine(t, false, 0, promqltest.DefaultMaxSamplesPerQuery)
+	ctx := context.Background()
+
+	const qs = `sum by (job) (rate(metric[1m]))`
+	start, end, interval := time.Unix(60, 0), time.Unix(1000, 0), 10*time.Second
+
+	direct, err := engine.NewRangeQuery(ctx, st, nil, qs, start, end, interval)
+	require.NoError(t, err)
+	want := direct.Exec(ctx)
+	require.NoError(t, want.Err)
+
+	// The data lives close to the epoch, far outside the head window, so
+	// every split is cacheable.
+	cache := newMapResultCache()
+	cq := promql.NewCachedRangeQuerier(engine, st, cache, 5*time.Minute, time.Hour)
+
+	res := cq.RangeQuery(ctx, nil, qs, start, end, interval)
+	require.NoError(t, res.Err)
+	require.Equal(t, want.Value, res.Value)
+	require.Zero(t, cache.hits)
+	nSplits := cache.misses
+	require.Greater(t, nSplits, 1)
+
+	// The second run is served entirely from the cache, including the
+	// warnings of the original evaluation.
+	res = cq.RangeQuery(ctx, nil, qs, start, end, interval)
+	require.NoError(t, res.Err)
+	require.Equal(t, want.Value, res.Value)
+	require.Equal(t, want.Warnings, res.Warnings)
+	require.Equal(t, nSplits, cache.hits)
+	require.Equal(t, nSplits, cache.misses)
+
+	// A different lookback delta evaluates differently and must not share
+	// cache entries with the runs above.
+	opts := promql.NewPrometheusQueryOpts(false, time.Minute)
+	res = cq.RangeQuery(ctx, opts, qs, start, end, interval)
+	require.NoError(t, res.Err)
+	require.Equal(t, nSplits, cache.hits)
+	require.Equal(t, 2*nSplits, cache.misses)
+
+	// Entries holding an empty Matrix are still hits: a selector matching
+	// nothing must not be re-evaluated on every run.
+	emptyQuery := `sum by (job) (rate(absent_metric[1m]))`
+	res = cq.RangeQuery(ctx, nil, emptyQuery, start, end, interval)
+	require.NoError(t, res.Err)
+	require.Equal(t, nSplits, cache.hits)
+	res = cq.RangeQuery(ctx, nil, emptyQuery, start, end, interval)
+	require.NoError(t, res.Err)
+	require.Equal(t, 2*nSplits, cache.hits)
+
+	// An inverted time range is an error, not an empty result.
+	res = cq.RangeQuery(ctx, nil, qs, end, start, interval)
+	require.ErrorContains(t, res.Err, "end timestamp")
+}
+
+func TestCachedRangeQuerierHeadWindow(t *testing.T) {
+	st := promqltest.LoadedStorage(t, `
+load 10s
+  metric 0+1x100
+`)
+	t.Cleanup(func() { st.Close() })
+
+	engine := promqltest.NewTestEngine(t, false, 0, promqltest.DefaultMaxSamplesPerQuery)
+	ctx := context.Background()
+
+	// A head window reaching back past the data makes every split mutable:
+	// nothing may be cached.
+	cache := newMapResultCache()
+	cq := promql.NewCachedRangeQuerier(engine, st, cache, 5*time.Minute, time.Since(time.Unix(0, 0)))
+
+	res := cq.RangeQuery(ctx, nil, `metric`, time.Unix(0, 0), time.Unix(1000, 0), 10*time.Second)
+	require.NoError(t, res.Err)
+	require.Zero(t, cache.hits+cache.misses)
+	require.Empty(t, cache.entries)
+}
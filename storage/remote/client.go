@@ -121,8 +121,7 @@ type Client struct {
 	readQueriesTotal    *prometheus.CounterVec
 	readQueriesDuration prometheus.ObserverVec
 
-	writeProtoMsg    config.RemoteWriteProtoMsg
-	writeCompression compression.Type // Not exposed by ClientConfig for now.
+	writeProtoMsg config.RemoteWriteProtoMsg
 }
 
 // ClientConfig configures a client.
@@ -226,15 +225,15 @@ func NewWriteClient(name string, conf *ClientConfig) (WriteClient, error) {
 		otelhttp.WithClientTrace(func(ctx context.Context) *httptrace.ClientTrace {
 			return otelhttptrace.NewClientTrace(ctx, otelhttptrace.WithoutSubSpans())
 		}))
-	return &Client{
+	c := &Client{
 		remoteName:       name,
 		urlString:        conf.URL.String(),
 		Client:           httpClient,
 		retryOnRateLimit: conf.RetryOnRateLimit,
 		timeout:          time.Duration(conf.Timeout),
 		writeProtoMsg:    writeProtoMsg,
-		writeCompression: compression.Snappy,
-	}, nil
+	}
+	return c, nil
 }
 
 func newInjectHeadersRoundTripper(h map[string]string, underlyingRT http.RoundTripper) *injectHeadersRoundTripper {
@@ -255,14 +254,20 @@ func (t *injectHeadersRoundTripper) RoundTrip(req *http.Request) (*http.Response
 
 const defaultBackoff = 0
 
+// errUnsupportedMedia is returned on HTTP 415 responses. When sending with
+// non-default compression this likely means the receiver doesn't support it
+// and the sender should fall back to snappy.
+var errUnsupportedMedia = errors.New("server rejected the request as unsupported media")
+
 type RecoverableError struct {
 	error
 	retryAfter model.Duration
 }
 
 // Store sends a batch of samples to the HTTP endpoint, the request is the proto marshalled
-// and encoded bytes from codec.go.
-func (c *Client) Store(ctx context.Context, req []byte, attempt int) (WriteResponseStats, error) {
+// and encoded bytes from codec.go. compr is the compression the request body
+// was encoded with and defaults to snappy when empty.
+func (c *Client) Store(ctx context.Context, req []byte, attempt int, compr compression.Type) (WriteResponseStats, error) {
 	httpReq, err := http.NewRequest(http.MethodPost, c.urlString, bytes.NewReader(req))
 	if err != nil {
 		// Errors from NewRequest are from unparsable URLs, so are not
@@ -270,7 +275,10 @@ func (c *Client) Store(ctx context.Context, req []byte, attempt int) (WriteRespo
 		return WriteResponseStats{}, err
 	}
 
-	httpReq.Header.Add("Content-Encoding", c.writeCompression)
+	if compr == "" {
+		compr = compression.Snappy
+	}
+	httpReq.Header.Add("Content-Encoding", compr)
 	httpReq.Header.Set("Content-Type", remoteWriteContentTypeHeaders[c.writeProtoMsg])
 	httpReq.Header.Set("User-Agent", UserAgent)
 	if c.writeProtoMsg == config.RemoteWriteProtoMsgV1 {
@@ -314,6 +322,9 @@ func (c *Client) Store(ctx context.Context, req []byte, attempt int) (WriteRespo
 	// TODO(bwplotka): Pass logger and emit debug on error?
 	body, _ := io.ReadAll(io.LimitReader(httpResp.Body, maxErrMsgLen))
 	err = fmt.Errorf("server returned HTTP status %s: %s", httpResp.Status, body)
+	if httpResp.StatusCode == http.StatusUnsupportedMediaType {
+		err = fmt.Errorf("%w: %w", errUnsupportedMedia, err)
+	}
 
 	if httpResp.StatusCode/100 == 5 ||
 		(c.retryOnRateLimit && httpResp.StatusCode == http.StatusTooManyRequests) {
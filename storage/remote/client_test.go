@@ -35,6 +35,7 @@ import (
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/util/compression"
 	"github.com/prometheus/prometheus/util/testutil"
 )
 
@@ -83,7 +84,7 @@ func TestStoreHTTPErrorHandling(t *testing.T) {
 		c, err := NewWriteClient(hash, conf)
 		require.NoError(t, err)
 
-		_, err = c.Store(context.Background(), []byte{}, 0)
+		_, err = c.Store(context.Background(), []byte{}, 0, compression.Snappy)
 		if test.err != nil {
 			require.EqualError(t, err, test.err.Error())
 		} else {
@@ -143,7 +144,7 @@ func TestClientRetryAfter(t *testing.T) {
 			c := getClient(getClientConfig(serverURL, tc.retryOnRateLimit))
 
 			var recErr RecoverableError
-			_, err = c.Store(context.Background(), []byte{}, 0)
+			_, err = c.Store(context.Background(), []byte{}, 0, compression.Snappy)
 			require.Equal(t, tc.expectedRecoverable, errors.As(err, &recErr), "Mismatch in expected recoverable error status.")
 			if tc.expectedRecoverable {
 				require.Equal(t, tc.expectedRetryAfter, recErr.retryAfter)
@@ -213,7 +214,7 @@ func TestClientCustomHeaders(t *testing.T) {
 	c, err := NewWriteClient("c", conf)
 	require.NoError(t, err)
 
-	_, err = c.Store(context.Background(), []byte{}, 0)
+	_, err = c.Store(context.Background(), []byte{}, 0, compression.Snappy)
 	require.NoError(t, err)
 
 	require.True(t, called, "The remote server wasn't called")
@@ -0,0 +1,85 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// Message types of dead letter file records.
+const (
+	// deadLetterV1Message marks a record holding a marshaled
+	// prometheus.WriteRequest protobuf message.
+	deadLetterV1Message = byte(1)
+	// deadLetterV2Message marks a record holding a marshaled
+	// io.prometheus.write.v2.Request protobuf message.
+	deadLetterV2Message = byte(2)
+)
+
+// deadLetterDir is created inside the storage directory and holds one dead
+// letter file per remote write endpoint, named after the endpoint.
+const deadLetterDir = "dead_letter"
+
+// maybeDeadLetter appends the given uncompressed write request protobuf
+// message to the endpoint's dead letter file, if the endpoint is configured
+// with the dead-letter policy for non-retryable errors. Each record consists
+// of a one byte message type followed by the message length as a big-endian
+// uint64 and the message itself. The file grows without bound; it is up to
+// the operator to process and truncate it.
+//
+// Writing to the dead letter file is best effort: a failure to spill is
+// logged, but the data is dropped either way and the caller's error handling
+// is unaffected.
+func (t *QueueManager) maybeDeadLetter(msgType byte, msg []byte, sendErr error) {
+	if t.cfg.NonRetryableErrorPolicy != config.NonRetryableErrorPolicyDeadLetter {
+		return
+	}
+
+	t.deadLetterMtx.Lock()
+	defer t.deadLetterMtx.Unlock()
+
+	if t.deadLetterFile == nil {
+		dir := filepath.Join(t.dir, deadLetterDir)
+		if err := os.MkdirAll(dir, 0o777); err != nil {
+			t.logger.Error("Failed to create dead letter directory", "dir", dir, "err", err)
+			return
+		}
+		f, err := os.OpenFile(filepath.Join(dir, t.client().Name()), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o666)
+		if err != nil {
+			t.logger.Error("Failed to open dead letter file", "err", err)
+			return
+		}
+		t.deadLetterFile = f
+	}
+
+	var header [9]byte
+	header[0] = msgType
+	binary.BigEndian.PutUint64(header[1:], uint64(len(msg)))
+	_, err := t.deadLetterFile.Write(header[:])
+	if err == nil {
+		_, err = t.deadLetterFile.Write(msg)
+	}
+	if err != nil {
+		// The file may be left with a partial record, which readers have to
+		// be prepared for anyway as we don't fsync records.
+		t.logger.Error("Failed to write to dead letter file", "err", err, "sendErr", sendErr)
+		return
+	}
+	t.metrics.deadLetteredBytesTotal.Add(float64(len(header) + len(msg)))
+	t.logger.Warn("Wrote rejected write request to dead letter file", "file", t.deadLetterFile.Name(), "bytes", len(msg), "err", sendErr)
+}
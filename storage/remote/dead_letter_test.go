@@ -0,0 +1,82 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func TestMaybeDeadLetter(t *testing.T) {
+	payload := []byte("marshaled write request")
+
+	t.Run("dead-letter policy spills records", func(t *testing.T) {
+		cfg := config.DefaultQueueConfig
+		cfg.NonRetryableErrorPolicy = config.NonRetryableErrorPolicyDeadLetter
+		c := NewTestWriteClient(config.RemoteWriteProtoMsgV1)
+		m := newTestQueueManager(t, cfg, config.DefaultMetadataConfig, defaultFlushDeadline, c, config.RemoteWriteProtoMsgV1)
+
+		m.maybeDeadLetter(deadLetterV1Message, payload, errors.New("boom"))
+		m.maybeDeadLetter(deadLetterV2Message, payload, errors.New("boom"))
+
+		b, err := os.ReadFile(filepath.Join(m.dir, deadLetterDir, c.Name()))
+		require.NoError(t, err)
+		require.Len(t, b, 2*(9+len(payload)))
+		for _, msgType := range []byte{deadLetterV1Message, deadLetterV2Message} {
+			require.Equal(t, msgType, b[0])
+			require.Equal(t, uint64(len(payload)), binary.BigEndian.Uint64(b[1:9]))
+			require.Equal(t, payload, b[9:9+len(payload)])
+			b = b[9+len(payload):]
+		}
+	})
+
+	t.Run("drop policy does not spill", func(t *testing.T) {
+		c := NewTestWriteClient(config.RemoteWriteProtoMsgV1)
+		m := newTestQueueManager(t, config.DefaultQueueConfig, config.DefaultMetadataConfig, defaultFlushDeadline, c, config.RemoteWriteProtoMsgV1)
+
+		m.maybeDeadLetter(deadLetterV1Message, payload, errors.New("boom"))
+
+		_, err := os.Stat(filepath.Join(m.dir, deadLetterDir))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestBlockOnNonRetryableError(t *testing.T) {
+	cfg := config.DefaultQueueConfig
+	cfg.NonRetryableErrorPolicy = config.NonRetryableErrorPolicyBlock
+	c := NewTestWriteClient(config.RemoteWriteProtoMsgV1)
+	m := newTestQueueManager(t, cfg, config.DefaultMetadataConfig, defaultFlushDeadline, c, config.RemoteWriteProtoMsgV1)
+
+	// With the block policy a non-retryable error is retried like a
+	// recoverable one instead of being returned.
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := m.sendWriteRequestWithBackoff(ctx, func(int) error {
+		attempts++
+		if attempts == 3 {
+			cancel()
+		}
+		return errors.New("bad request")
+	}, func() {})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 3, attempts)
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage"
+)
+
+// failoverReadClient is a ReadClient that spreads reads over a set of replica
+// clients serving the same data. Replicas are tried in configuration order;
+// if one fails, the next is tried. With a non-zero hedge delay the next
+// replica is additionally started whenever the current attempt has not
+// returned within that delay, and the first successful response wins.
+//
+// Because only one replica's response is ever returned, results are
+// deduplicated by construction; the replicas are assumed to hold the same
+// series.
+type failoverReadClient struct {
+	name       string
+	clients    []ReadClient
+	hedgeDelay time.Duration
+	logger     *slog.Logger
+}
+
+// NewFailoverReadClient returns a ReadClient that fails over across the given
+// replica clients, hedging requests after hedgeDelay if it is non-zero.
+func NewFailoverReadClient(name string, clients []ReadClient, hedgeDelay time.Duration, logger *slog.Logger) ReadClient {
+	if len(clients) == 1 {
+		return clients[0]
+	}
+	return &failoverReadClient{
+		name:       name,
+		clients:    clients,
+		hedgeDelay: hedgeDelay,
+		logger:     logger,
+	}
+}
+
+// Read implements ReadClient.
+func (c *failoverReadClient) Read(ctx context.Context, query *prompb.Query, sortSeries bool) (storage.SeriesSet, error) {
+	return c.do(ctx, func(ctx context.Context, cl ReadClient) (storage.SeriesSet, error) {
+		return cl.Read(ctx, query, sortSeries)
+	})
+}
+
+// ReadMultiple implements ReadClient.
+func (c *failoverReadClient) ReadMultiple(ctx context.Context, queries []*prompb.Query, sortSeries bool) (storage.SeriesSet, error) {
+	return c.do(ctx, func(ctx context.Context, cl ReadClient) (storage.SeriesSet, error) {
+		return cl.ReadMultiple(ctx, queries, sortSeries)
+	})
+}
+
+func (c *failoverReadClient) do(ctx context.Context, read func(context.Context, ReadClient) (storage.SeriesSet, error)) (storage.SeriesSet, error) {
+	if c.hedgeDelay <= 0 {
+		return c.sequentialDo(ctx, read)
+	}
+	return c.hedgedDo(ctx, read)
+}
+
+// sequentialDo tries each replica in order, returning the first successful
+// response.
+func (c *failoverReadClient) sequentialDo(ctx context.Context, read func(context.Context, ReadClient) (storage.SeriesSet, error)) (storage.SeriesSet, error) {
+	var errs []error
+	for i, cl := range c.clients {
+		res, err := read(ctx, cl)
+		if err == nil {
+			return res, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		c.logger.Warn("Remote read replica failed, failing over", "group", c.name, "replica", i, "err", err)
+		errs = append(errs, fmt.Errorf("replica %d: %w", i, err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// hedgedDo starts the first replica immediately and a further replica each
+// time the outstanding attempts have neither succeeded nor all failed within
+// the hedge delay. The first successful response wins and the remaining
+// in-flight attempts are cancelled.
+func (c *failoverReadClient) hedgedDo(ctx context.Context, read func(context.Context, ReadClient) (storage.SeriesSet, error)) (storage.SeriesSet, error) {
+	type result struct {
+		replica int
+		set     storage.SeriesSet
+		err     error
+	}
+	var (
+		results = make(chan result, len(c.clients))
+		cancels = make([]context.CancelFunc, len(c.clients))
+		started = 0
+	)
+	start := func() {
+		i := started
+		rctx, cancel := context.WithCancel(ctx)
+		cancels[i] = cancel
+		started++
+		go func() {
+			set, err := read(rctx, c.clients[i])
+			results <- result{replica: i, set: set, err: err}
+		}()
+	}
+
+	start()
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	var errs []error
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			if started < len(c.clients) {
+				c.logger.Debug("Remote read replica slow, hedging to next replica", "group", c.name, "replica", started)
+				start()
+				timer.Reset(c.hedgeDelay)
+			}
+		case r := <-results:
+			if r.err == nil {
+				for i, cancel := range cancels[:started] {
+					if i != r.replica {
+						cancel()
+					}
+				}
+				// The winning attempt's context must outlive this call as the
+				// returned series set may still stream from it; release it
+				// together with the caller's context.
+				context.AfterFunc(ctx, cancels[r.replica])
+				return r.set, nil
+			}
+			cancels[r.replica]()
+			c.logger.Warn("Remote read replica failed", "group", c.name, "replica", r.replica, "err", r.err)
+			errs = append(errs, fmt.Errorf("replica %d: %w", r.replica, r.err))
+			if len(errs) == len(c.clients) {
+				return nil, errors.Join(errs...)
+			}
+			if started < len(c.clients) {
+				start()
+				timer.Reset(c.hedgeDelay)
+			}
+		}
+	}
+}
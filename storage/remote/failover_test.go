@@ -0,0 +1,181 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage"
+)
+
+// failoverTestClient is a ReadClient replica stub; a nil err returns a series
+// set with a single series labelled with the replica name, a non-zero delay
+// blocks the read until the delay passes or the context is cancelled.
+type failoverTestClient struct {
+	name  string
+	err   error
+	delay time.Duration
+
+	reads int
+}
+
+func (c *failoverTestClient) Read(ctx context.Context, _ *prompb.Query, _ bool) (storage.SeriesSet, error) {
+	c.reads++
+	if c.delay > 0 {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &concreteSeriesSet{series: []storage.Series{
+		storage.NewListSeries(labels.FromStrings("replica", c.name), nil),
+	}}, nil
+}
+
+func (c *failoverTestClient) ReadMultiple(ctx context.Context, _ []*prompb.Query, sortSeries bool) (storage.SeriesSet, error) {
+	return c.Read(ctx, nil, sortSeries)
+}
+
+func replicaOf(t *testing.T, ss storage.SeriesSet) string {
+	t.Helper()
+	require.True(t, ss.Next())
+	return ss.At().Labels().Get("replica")
+}
+
+func TestFailoverReadClient_Sequential(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		clients         []*failoverTestClient
+		expectedReplica string
+		expectedErr     string
+	}{
+		{
+			name: "first replica healthy",
+			clients: []*failoverTestClient{
+				{name: "a"},
+				{name: "b"},
+			},
+			expectedReplica: "a",
+		},
+		{
+			name: "first replica fails",
+			clients: []*failoverTestClient{
+				{name: "a", err: errors.New("boom")},
+				{name: "b"},
+			},
+			expectedReplica: "b",
+		},
+		{
+			name: "all replicas fail",
+			clients: []*failoverTestClient{
+				{name: "a", err: errors.New("boom a")},
+				{name: "b", err: errors.New("boom b")},
+			},
+			expectedErr: "replica 0: boom a\nreplica 1: boom b",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clients := make([]ReadClient, 0, len(tc.clients))
+			for _, c := range tc.clients {
+				clients = append(clients, c)
+			}
+			fc := NewFailoverReadClient("test", clients, 0, promslog.NewNopLogger())
+
+			ss, err := fc.Read(context.Background(), &prompb.Query{}, false)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedReplica, replicaOf(t, ss))
+		})
+	}
+}
+
+func TestFailoverReadClient_Hedged(t *testing.T) {
+	t.Run("slow first replica is hedged", func(t *testing.T) {
+		clients := []*failoverTestClient{
+			{name: "a", delay: time.Minute},
+			{name: "b"},
+		}
+		fc := NewFailoverReadClient("test", []ReadClient{clients[0], clients[1]}, 10*time.Millisecond, promslog.NewNopLogger())
+
+		ss, err := fc.Read(context.Background(), &prompb.Query{}, false)
+		require.NoError(t, err)
+		require.Equal(t, "b", replicaOf(t, ss))
+		require.Equal(t, 1, clients[1].reads)
+	})
+
+	t.Run("fast first replica is not hedged", func(t *testing.T) {
+		clients := []*failoverTestClient{
+			{name: "a"},
+			{name: "b"},
+		}
+		fc := NewFailoverReadClient("test", []ReadClient{clients[0], clients[1]}, time.Minute, promslog.NewNopLogger())
+
+		ss, err := fc.Read(context.Background(), &prompb.Query{}, false)
+		require.NoError(t, err)
+		require.Equal(t, "a", replicaOf(t, ss))
+		require.Equal(t, 0, clients[1].reads)
+	})
+
+	t.Run("failure starts next replica before the delay", func(t *testing.T) {
+		clients := []*failoverTestClient{
+			{name: "a", err: errors.New("boom")},
+			{name: "b"},
+		}
+		fc := NewFailoverReadClient("test", []ReadClient{clients[0], clients[1]}, time.Minute, promslog.NewNopLogger())
+
+		ss, err := fc.Read(context.Background(), &prompb.Query{}, false)
+		require.NoError(t, err)
+		require.Equal(t, "b", replicaOf(t, ss))
+	})
+
+	t.Run("all replicas fail", func(t *testing.T) {
+		clients := []*failoverTestClient{
+			{name: "a", err: errors.New("boom a")},
+			{name: "b", err: errors.New("boom b")},
+		}
+		fc := NewFailoverReadClient("test", []ReadClient{clients[0], clients[1]}, 10*time.Millisecond, promslog.NewNopLogger())
+
+		_, err := fc.Read(context.Background(), &prompb.Query{}, false)
+		require.ErrorContains(t, err, "boom a")
+		require.ErrorContains(t, err, "boom b")
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		clients := []*failoverTestClient{
+			{name: "a", delay: time.Minute},
+			{name: "b", delay: time.Minute},
+		}
+		fc := NewFailoverReadClient("test", []ReadClient{clients[0], clients[1]}, time.Millisecond, promslog.NewNopLogger())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := fc.Read(ctx, &prompb.Query{}, false)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"os"
 	"strconv"
 	"sync"
 	"time"
@@ -95,6 +96,7 @@ type queueManagerMetrics struct {
 	desiredNumShards       prometheus.Gauge
 	sentBytesTotal         prometheus.Counter
 	metadataBytesTotal     prometheus.Counter
+	deadLetteredBytesTotal prometheus.Counter
 	maxSamplesPerSend      prometheus.Gauge
 }
 
@@ -318,6 +320,13 @@ func newQueueManagerMetrics(r prometheus.Registerer, rn, e string) *queueManager
 		Help:        "The total number of bytes of metadata sent by the queue after compression.",
 		ConstLabels: constLabels,
 	})
+	m.deadLetteredBytesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   namespace,
+		Subsystem:   subsystem,
+		Name:        "dead_lettered_bytes_total",
+		Help:        "The total number of bytes of write request payloads written to the dead letter file after non-retryable errors.",
+		ConstLabels: constLabels,
+	})
 	m.maxSamplesPerSend = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace:   namespace,
 		Subsystem:   subsystem,
@@ -397,6 +406,7 @@ func (m *queueManagerMetrics) unregister() {
 		m.reg.Unregister(m.desiredNumShards)
 		m.reg.Unregister(m.sentBytesTotal)
 		m.reg.Unregister(m.metadataBytesTotal)
+		m.reg.Unregister(m.deadLetteredBytesTotal)
 		m.reg.Unregister(m.maxSamplesPerSend)
 	}
 }
@@ -404,8 +414,9 @@ func (m *queueManagerMetrics) unregister() {
 // WriteClient defines an interface for sending a batch of samples to an
 // external timeseries database.
 type WriteClient interface {
-	// Store stores the given samples in the remote storage.
-	Store(ctx context.Context, req []byte, retryAttempt int) (WriteResponseStats, error)
+	// Store stores the given samples in the remote storage. compr is the
+	// compression the request body was encoded with.
+	Store(ctx context.Context, req []byte, retryAttempt int, compr compression.Type) (WriteResponseStats, error)
 	// Name uniquely identifies the remote storage.
 	Name() string
 	// Endpoint is the remote read or write endpoint for the storage client.
@@ -420,6 +431,7 @@ type QueueManager struct {
 	buildRequestLimitTimestamp   atomic.Int64
 	reshardDisableStartTimestamp atomic.Int64 // Time that reshard was disabled.
 	reshardDisableEndTimestamp   atomic.Int64 // Time that reshard is disabled until.
+	comprFallback                atomic.Bool  // Set when the receiver rejected compr and we fell back to snappy.
 
 	logger                  *slog.Logger
 	flushDeadline           time.Duration
@@ -436,7 +448,7 @@ type QueueManager struct {
 	clientMtx   sync.RWMutex
 	storeClient WriteClient
 	protoMsg    config.RemoteWriteProtoMsg
-	compr       compression.Type
+	compr       compression.Type // The configured compression; see compression() for the effective one.
 
 	seriesMtx      sync.Mutex // Covers seriesLabels, seriesMetadata, droppedSeries and builder.
 	seriesLabels   map[chunks.HeadSeriesRef]labels.Labels
@@ -457,6 +469,10 @@ type QueueManager struct {
 
 	metrics  *queueManagerMetrics
 	interner *pool
+
+	dir            string
+	deadLetterMtx  sync.Mutex // Covers deadLetterFile.
+	deadLetterFile *os.File
 }
 
 // NewQueueManager builds a new QueueManager and starts a new
@@ -482,7 +498,11 @@ func NewQueueManager(
 	enableNativeHistogramRemoteWrite bool,
 	enableTypeAndUnitLabels bool,
 	protoMsg config.RemoteWriteProtoMsg,
+	compr compression.Type,
 ) *QueueManager {
+	if compr == "" {
+		compr = compression.Snappy
+	}
 	if logger == nil {
 		logger = promslog.NewNopLogger()
 	}
@@ -524,7 +544,9 @@ func NewQueueManager(
 		interner: interner,
 
 		protoMsg: protoMsg,
-		compr:    compression.Snappy, // Hardcoded for now, but scaffolding exists for likely future use.
+		compr:    compr,
+
+		dir: dir,
 	}
 
 	walMetadata := t.protoMsg != config.RemoteWriteProtoMsgV1
@@ -581,7 +603,8 @@ func (t *QueueManager) AppendWatcherMetadata(ctx context.Context, metadata []scr
 
 func (t *QueueManager) sendMetadataWithBackoff(ctx context.Context, metadata []prompb.MetricMetadata, pBuf *proto.Buffer) error {
 	// Build the WriteRequest with no samples (v1 flow).
-	req, _, _, err := buildWriteRequest(t.logger, nil, metadata, pBuf, nil, nil, t.compr)
+	compr := t.compression()
+	req, _, _, err := buildWriteRequest(t.logger, nil, metadata, pBuf, nil, nil, compr)
 	if err != nil {
 		return err
 	}
@@ -606,7 +629,7 @@ func (t *QueueManager) sendMetadataWithBackoff(ctx context.Context, metadata []p
 		begin := time.Now()
 		// Ignoring WriteResponseStats, because there is nothing for metadata, since it's
 		// embedded in v2 calls now, and we do v1 here.
-		_, err := t.storeClient.Store(ctx, req, try)
+		_, err := t.storeClient.Store(ctx, req, try, compr)
 		t.metrics.sentBatchDuration.Observe(time.Since(begin).Seconds())
 
 		if err != nil {
@@ -971,6 +994,14 @@ func (t *QueueManager) Stop() {
 	if t.mcfg.Send {
 		t.metadataWatcher.Stop()
 	}
+	t.deadLetterMtx.Lock()
+	if t.deadLetterFile != nil {
+		if err := t.deadLetterFile.Close(); err != nil {
+			t.logger.Error("Failed to close dead letter file", "err", err)
+		}
+		t.deadLetterFile = nil
+	}
+	t.deadLetterMtx.Unlock()
 	t.metrics.unregister()
 }
 
@@ -1057,6 +1088,24 @@ func (t *QueueManager) client() WriteClient {
 	return t.storeClient
 }
 
+// compression returns the compression to use for write requests: the
+// configured one, or snappy after the receiver rejected the configured one.
+func (t *QueueManager) compression() compression.Type {
+	if t.comprFallback.Load() {
+		return compression.Snappy
+	}
+	return t.compr
+}
+
+// fallbackToSnappyCompression switches the queue to snappy after the
+// receiver rejected the configured compression.
+func (t *QueueManager) fallbackToSnappyCompression(err error) {
+	if t.comprFallback.Swap(true) {
+		return
+	}
+	t.logger.Warn("Receiver rejected the configured remote write compression; falling back to snappy", "compression", t.compr, "err", err)
+}
+
 // processExternalLabels merges externalLabels into b. If b contains
 // a label in externalLabels, the value in b wins.
 func processExternalLabels(b *labels.Builder, externalLabels []labels.Label) {
@@ -1595,7 +1644,7 @@ func (s *shards) runShard(ctx context.Context, shardID int, queue *queue) {
 				return
 			}
 
-			sendBatch(batch, s.qm.protoMsg, s.qm.compr, false)
+			sendBatch(batch, s.qm.protoMsg, s.qm.compression(), false)
 			// TODO(bwplotka): Previously the return was between popular and send.
 			// Consider this when DRY-ing https://github.com/prometheus/prometheus/issues/14409
 			queue.ReturnForReuse(batch)
@@ -1606,7 +1655,7 @@ func (s *shards) runShard(ctx context.Context, shardID int, queue *queue) {
 		case <-timer.C:
 			batch := queue.Batch()
 			if len(batch) > 0 {
-				sendBatch(batch, s.qm.protoMsg, s.qm.compr, true)
+				sendBatch(batch, s.qm.protoMsg, s.qm.compression(), true)
 			}
 			queue.ReturnForReuse(batch)
 			timer.Reset(time.Duration(s.qm.cfg.BatchSendDeadline))
@@ -1657,7 +1706,7 @@ func populateTimeSeries(batch []timeSeries, pendingData []prompb.TimeSeries, sen
 
 func (s *shards) sendSamples(ctx context.Context, samples []prompb.TimeSeries, sampleCount, exemplarCount, histogramCount int, pBuf *proto.Buffer, buf compression.EncodeBuffer, compr compression.Type) error {
 	begin := time.Now()
-	rs, err := s.sendSamplesWithBackoff(ctx, samples, sampleCount, exemplarCount, histogramCount, 0, pBuf, buf, compr)
+	rs, err := s.sendSamplesWithBackoff(ctx, samples, sampleCount, exemplarCount, histogramCount, 0, pBuf, buf, compr, false)
 	s.updateMetrics(ctx, err, sampleCount, exemplarCount, histogramCount, 0, rs, time.Since(begin))
 	return err
 }
@@ -1666,7 +1715,7 @@ func (s *shards) sendSamples(ctx context.Context, samples []prompb.TimeSeries, s
 // See https://github.com/prometheus/prometheus/issues/14409
 func (s *shards) sendV2Samples(ctx context.Context, samples []writev2.TimeSeries, labels []string, sampleCount, exemplarCount, histogramCount, metadataCount int, pBuf *[]byte, buf compression.EncodeBuffer, compr compression.Type) error {
 	begin := time.Now()
-	rs, err := s.sendV2SamplesWithBackoff(ctx, samples, labels, sampleCount, exemplarCount, histogramCount, metadataCount, pBuf, buf, compr)
+	rs, err := s.sendV2SamplesWithBackoff(ctx, samples, labels, sampleCount, exemplarCount, histogramCount, metadataCount, pBuf, buf, compr, false)
 	s.updateMetrics(ctx, err, sampleCount, exemplarCount, histogramCount, metadataCount, rs, time.Since(begin))
 	return err
 }
@@ -1708,7 +1757,9 @@ func (s *shards) updateMetrics(_ context.Context, err error, sampleCount, exempl
 }
 
 // sendSamplesWithBackoff to the remote storage with backoff for recoverable errors.
-func (s *shards) sendSamplesWithBackoff(ctx context.Context, samples []prompb.TimeSeries, sampleCount, exemplarCount, histogramCount, metadataCount int, pBuf *proto.Buffer, buf compression.EncodeBuffer, compr compression.Type) (WriteResponseStats, error) {
+// resent marks a batch that is being sent again after a compression fallback, so
+// its first attempt is not counted towards the sent-data metrics a second time.
+func (s *shards) sendSamplesWithBackoff(ctx context.Context, samples []prompb.TimeSeries, sampleCount, exemplarCount, histogramCount, metadataCount int, pBuf *proto.Buffer, buf compression.EncodeBuffer, compr compression.Type, resent bool) (WriteResponseStats, error) {
 	// Build the WriteRequest with no metadata.
 	req, highest, lowest, err := buildWriteRequest(s.qm.logger, samples, nil, pBuf, nil, buf, compr)
 	s.qm.buildRequestLimitTimestamp.Store(lowest)
@@ -1773,13 +1824,17 @@ func (s *shards) sendSamplesWithBackoff(ctx context.Context, samples []prompb.Ti
 		}
 
 		begin := time.Now()
-		s.qm.metrics.samplesTotal.Add(float64(sampleCount))
-		s.qm.metrics.exemplarsTotal.Add(float64(exemplarCount))
-		s.qm.metrics.histogramsTotal.Add(float64(histogramCount))
-		s.qm.metrics.metadataTotal.Add(float64(metadataCount))
+		if !resent || try > 0 {
+			// The first attempt of a resent batch was already counted when it
+			// failed with the original compression.
+			s.qm.metrics.samplesTotal.Add(float64(sampleCount))
+			s.qm.metrics.exemplarsTotal.Add(float64(exemplarCount))
+			s.qm.metrics.histogramsTotal.Add(float64(histogramCount))
+			s.qm.metrics.metadataTotal.Add(float64(metadataCount))
+		}
 		// Technically for v1, we will likely have empty response stats, but for
 		// newer Receivers this might be not, so used it in a best effort.
-		rs, err := s.qm.client().Store(ctx, req, try)
+		rs, err := s.qm.client().Store(ctx, req, try, compr)
 		s.qm.metrics.sentBatchDuration.Observe(time.Since(begin).Seconds())
 		// TODO(bwplotka): Revisit this once we have Receivers doing retriable partial error
 		// so far we don't have those, so it's ok to potentially skew statistics.
@@ -1804,6 +1859,15 @@ func (s *shards) sendSamplesWithBackoff(ctx context.Context, samples []prompb.Ti
 		// So we exit early to not update the metrics.
 		return accumulatedStats, err
 	}
+	if errors.Is(err, errUnsupportedMedia) && compr != compression.Snappy {
+		// The receiver likely doesn't support the configured compression.
+		// Fall back to snappy for this and all subsequent requests.
+		s.qm.fallbackToSnappyCompression(err)
+		return s.sendSamplesWithBackoff(ctx, samples, sampleCount, exemplarCount, histogramCount, metadataCount, pBuf, buf, compression.Snappy, true)
+	}
+	if err != nil {
+		s.qm.maybeDeadLetter(deadLetterV1Message, pBuf.Bytes(), err)
+	}
 
 	s.qm.metrics.sentBytesTotal.Add(float64(reqSize))
 	s.qm.metrics.highestSentTimestamp.Set(float64(highest / 1000))
@@ -1821,7 +1885,9 @@ func (s *shards) sendSamplesWithBackoff(ctx context.Context, samples []prompb.Ti
 }
 
 // sendV2SamplesWithBackoff to the remote storage with backoff for recoverable errors.
-func (s *shards) sendV2SamplesWithBackoff(ctx context.Context, samples []writev2.TimeSeries, labels []string, sampleCount, exemplarCount, histogramCount, metadataCount int, pBuf *[]byte, buf compression.EncodeBuffer, compr compression.Type) (WriteResponseStats, error) {
+// resent marks a batch that is being sent again after a compression fallback, so
+// its first attempt is not counted towards the sent-data metrics a second time.
+func (s *shards) sendV2SamplesWithBackoff(ctx context.Context, samples []writev2.TimeSeries, labels []string, sampleCount, exemplarCount, histogramCount, metadataCount int, pBuf *[]byte, buf compression.EncodeBuffer, compr compression.Type, resent bool) (WriteResponseStats, error) {
 	// Build the WriteRequest with no metadata.
 	req, highest, lowest, err := buildV2WriteRequest(s.qm.logger, samples, labels, pBuf, nil, buf, compr)
 	s.qm.buildRequestLimitTimestamp.Store(lowest)
@@ -1886,11 +1952,15 @@ func (s *shards) sendV2SamplesWithBackoff(ctx context.Context, samples []writev2
 		}
 
 		begin := time.Now()
-		s.qm.metrics.samplesTotal.Add(float64(sampleCount))
-		s.qm.metrics.exemplarsTotal.Add(float64(exemplarCount))
-		s.qm.metrics.histogramsTotal.Add(float64(histogramCount))
-		s.qm.metrics.metadataTotal.Add(float64(metadataCount))
-		rs, err := s.qm.client().Store(ctx, req, try)
+		if !resent || try > 0 {
+			// The first attempt of a resent batch was already counted when it
+			// failed with the original compression.
+			s.qm.metrics.samplesTotal.Add(float64(sampleCount))
+			s.qm.metrics.exemplarsTotal.Add(float64(exemplarCount))
+			s.qm.metrics.histogramsTotal.Add(float64(histogramCount))
+			s.qm.metrics.metadataTotal.Add(float64(metadataCount))
+		}
+		rs, err := s.qm.client().Store(ctx, req, try, compr)
 		s.qm.metrics.sentBatchDuration.Observe(time.Since(begin).Seconds())
 		// TODO(bwplotka): Revisit this once we have Receivers doing retriable partial error
 		// so far we don't have those, so it's ok to potentially skew statistics.
@@ -1926,6 +1996,15 @@ func (s *shards) sendV2SamplesWithBackoff(ctx context.Context, samples []writev2
 		// So we exit early to not update the metrics.
 		return accumulatedStats, err
 	}
+	if errors.Is(err, errUnsupportedMedia) && compr != compression.Snappy {
+		// The receiver likely doesn't support the configured compression.
+		// Fall back to snappy for this and all subsequent requests.
+		s.qm.fallbackToSnappyCompression(err)
+		return s.sendV2SamplesWithBackoff(ctx, samples, labels, sampleCount, exemplarCount, histogramCount, metadataCount, pBuf, buf, compression.Snappy, true)
+	}
+	if err != nil {
+		s.qm.maybeDeadLetter(deadLetterV2Message, *pBuf, err)
+	}
 
 	s.qm.metrics.sentBytesTotal.Add(float64(reqSize))
 	s.qm.metrics.highestSentTimestamp.Set(float64(highest / 1000))
@@ -2009,10 +2088,20 @@ func (t *QueueManager) sendWriteRequestWithBackoff(ctx context.Context, attempt
 			return nil
 		}
 
-		// If the error is unrecoverable, we should not retry.
+		// If the error is unrecoverable, we should not retry, unless the
+		// endpoint is configured to block on such errors.
 		var backoffErr RecoverableError
 		if !errors.As(err, &backoffErr) {
-			return err
+			if errors.Is(err, errUnsupportedMedia) {
+				// Retrying will keep sending the same rejected compression;
+				// return so the caller can fall back to snappy, even when
+				// blocking on non-retryable errors.
+				return err
+			}
+			if t.cfg.NonRetryableErrorPolicy != config.NonRetryableErrorPolicyBlock {
+				return err
+			}
+			t.logger.Error("Blocking on non-retryable error as configured", "err", err)
 		}
 
 		sleepDuration = backoff
@@ -312,7 +312,7 @@ func newTestClientAndQueueManager(t testing.TB, flushDeadline time.Duration, pro
 func newTestQueueManager(t testing.TB, cfg config.QueueConfig, mcfg config.MetadataConfig, deadline time.Duration, c WriteClient, protoMsg config.RemoteWriteProtoMsg) *QueueManager {
 	dir := t.TempDir()
 	metrics := newQueueManagerMetrics(nil, "", "")
-	m := NewQueueManager(metrics, nil, nil, nil, dir, cfg, mcfg, labels.EmptyLabels(), nil, c, deadline, newPool(), nil, false, false, false, protoMsg)
+	m := NewQueueManager(metrics, nil, nil, nil, dir, cfg, mcfg, labels.EmptyLabels(), nil, c, deadline, newPool(), nil, false, false, false, protoMsg, compression.Snappy)
 
 	return m
 }
@@ -759,7 +759,7 @@ func TestDisableReshardOnRetry(t *testing.T) {
 		metrics = newQueueManagerMetrics(nil, "", "")
 
 		client = &MockWriteClient{
-			StoreFunc: func(context.Context, []byte, int) (WriteResponseStats, error) {
+			StoreFunc: func(context.Context, []byte, int, compression.Type) (WriteResponseStats, error) {
 				onStoreCalled()
 
 				return WriteResponseStats{}, RecoverableError{
@@ -772,7 +772,7 @@ func TestDisableReshardOnRetry(t *testing.T) {
 		}
 	)
 
-	m := NewQueueManager(metrics, nil, nil, nil, "", cfg, mcfg, labels.EmptyLabels(), nil, client, 0, newPool(), nil, false, false, false, config.RemoteWriteProtoMsgV1)
+	m := NewQueueManager(metrics, nil, nil, nil, "", cfg, mcfg, labels.EmptyLabels(), nil, client, 0, newPool(), nil, false, false, false, config.RemoteWriteProtoMsgV1, compression.Snappy)
 	m.StoreSeries(fakeSeries, 0)
 
 	// Attempt to samples while the manager is running. We immediately stop the
@@ -806,6 +806,70 @@ func TestDisableReshardOnRetry(t *testing.T) {
 	}, time.Minute, retryAfter, "shouldReshard should have been re-enabled")
 }
 
+// snappyOnlyClient wraps a TestWriteClient and rejects any request that is not
+// snappy-compressed with HTTP 415, like a receiver that only understands the
+// snappy baseline.
+type snappyOnlyClient struct {
+	*TestWriteClient
+
+	comprMtx sync.Mutex
+	comprs   []compression.Type
+}
+
+func (c *snappyOnlyClient) Store(ctx context.Context, req []byte, attempt int, compr compression.Type) (WriteResponseStats, error) {
+	c.comprMtx.Lock()
+	c.comprs = append(c.comprs, compr)
+	c.comprMtx.Unlock()
+
+	if compr != compression.Snappy {
+		return WriteResponseStats{}, fmt.Errorf("server returned HTTP status 415 Unsupported Media Type: %w", errUnsupportedMedia)
+	}
+	return c.TestWriteClient.Store(ctx, req, attempt, compr)
+}
+
+func TestCompressionFallbackOn415(t *testing.T) {
+	t.Parallel()
+	inner := NewTestWriteClient(config.RemoteWriteProtoMsgV1)
+	c := &snappyOnlyClient{TestWriteClient: inner}
+
+	cfg := testDefaultQueueConfig()
+	cfg.MaxShards = 1
+	// The 415 must reach the fallback even when the queue is configured to
+	// block on non-retryable errors; otherwise it would retry zstd forever.
+	cfg.NonRetryableErrorPolicy = config.NonRetryableErrorPolicyBlock
+	mcfg := config.DefaultMetadataConfig
+	metrics := newQueueManagerMetrics(nil, "", "")
+	m := NewQueueManager(metrics, nil, nil, nil, t.TempDir(), cfg, mcfg, labels.EmptyLabels(), nil, c, defaultFlushDeadline, newPool(), nil, false, false, false, config.RemoteWriteProtoMsgV1, compression.Zstd)
+
+	samples, series := createTimeseries(1, 1)
+	m.StoreSeries(series, 0)
+	inner.expectSamples(samples, series)
+
+	m.Start()
+	defer m.Stop()
+
+	m.Append(samples)
+	inner.waitForExpectedData(t, 30*time.Second)
+
+	// The rejected zstd attempt and the snappy resend carry the same batch, so
+	// it must only be counted once.
+	require.Equal(t, float64(len(samples)), client_testutil.ToFloat64(m.metrics.samplesTotal))
+
+	// The fallback is sticky: subsequent batches go straight out as snappy.
+	require.Equal(t, compression.Snappy, m.compression())
+	inner.expectSamples(samples, series)
+	m.Append(samples)
+	inner.waitForExpectedData(t, 30*time.Second)
+
+	c.comprMtx.Lock()
+	defer c.comprMtx.Unlock()
+	require.GreaterOrEqual(t, len(c.comprs), 3)
+	require.Equal(t, compression.Zstd, c.comprs[0])
+	for _, compr := range c.comprs[1:] {
+		require.Equal(t, compression.Snappy, compr)
+	}
+}
+
 func createTimeseries(numSamples, numSeries int, extraLabels ...labels.Label) ([]record.RefSample, []record.RefSeries) {
 	samples := make([]record.RefSample, 0, numSamples)
 	series := make([]record.RefSeries, 0, numSeries)
@@ -1157,7 +1221,7 @@ func (c *TestWriteClient) SetReturnError(err error) {
 	c.returnError = err
 }
 
-func (c *TestWriteClient) Store(_ context.Context, req []byte, _ int) (WriteResponseStats, error) {
+func (c *TestWriteClient) Store(_ context.Context, req []byte, _ int, _ compression.Type) (WriteResponseStats, error) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 	if c.storeWait > 0 {
@@ -1321,7 +1385,7 @@ func NewTestBlockedWriteClient() *TestBlockingWriteClient {
 	return &TestBlockingWriteClient{}
 }
 
-func (c *TestBlockingWriteClient) Store(ctx context.Context, _ []byte, _ int) (WriteResponseStats, error) {
+func (c *TestBlockingWriteClient) Store(ctx context.Context, _ []byte, _ int, _ compression.Type) (WriteResponseStats, error) {
 	c.numCalls.Inc()
 	<-ctx.Done()
 	return WriteResponseStats{}, nil
@@ -1343,20 +1407,20 @@ func (*TestBlockingWriteClient) Endpoint() string {
 type NopWriteClient struct{}
 
 func NewNopWriteClient() *NopWriteClient { return &NopWriteClient{} }
-func (*NopWriteClient) Store(context.Context, []byte, int) (WriteResponseStats, error) {
+func (*NopWriteClient) Store(context.Context, []byte, int, compression.Type) (WriteResponseStats, error) {
 	return WriteResponseStats{}, nil
 }
 func (*NopWriteClient) Name() string     { return "nopwriteclient" }
 func (*NopWriteClient) Endpoint() string { return "http://test-remote.com/1234" }
 
 type MockWriteClient struct {
-	StoreFunc    func(context.Context, []byte, int) (WriteResponseStats, error)
+	StoreFunc    func(context.Context, []byte, int, compression.Type) (WriteResponseStats, error)
 	NameFunc     func() string
 	EndpointFunc func() string
 }
 
-func (c *MockWriteClient) Store(ctx context.Context, bb []byte, n int) (WriteResponseStats, error) {
-	return c.StoreFunc(ctx, bb, n)
+func (c *MockWriteClient) Store(ctx context.Context, bb []byte, n int, compr compression.Type) (WriteResponseStats, error) {
+	return c.StoreFunc(ctx, bb, n, compr)
 }
 func (c *MockWriteClient) Name() string     { return c.NameFunc() }
 func (c *MockWriteClient) Endpoint() string { return c.EndpointFunc() }
@@ -1461,7 +1525,7 @@ func BenchmarkStoreSeries(b *testing.B) {
 				mcfg := config.DefaultMetadataConfig
 				metrics := newQueueManagerMetrics(nil, "", "")
 
-				m := NewQueueManager(metrics, nil, nil, nil, dir, cfg, mcfg, labels.EmptyLabels(), nil, c, defaultFlushDeadline, newPool(), nil, false, false, false, config.RemoteWriteProtoMsgV1)
+				m := NewQueueManager(metrics, nil, nil, nil, dir, cfg, mcfg, labels.EmptyLabels(), nil, c, defaultFlushDeadline, newPool(), nil, false, false, false, config.RemoteWriteProtoMsgV1, compression.Snappy)
 				m.externalLabels = tc.externalLabels
 				m.relabelConfigs = tc.relabelConfigs
 
@@ -96,6 +96,11 @@ func (s *Storage) ApplyConfig(conf *config.Config) error {
 	// Update read clients
 	readHashes := make(map[string]struct{})
 	queryables := make([]storage.SampleAndChunkQueryable, 0, len(conf.RemoteReadConfigs))
+	// Endpoints sharing a failover_group become one queryable backed by a
+	// failover client; the group takes the slot of its first member.
+	groupClients := make(map[string][]ReadClient)
+	groupConfs := make(map[string]*config.RemoteReadConfig)
+	groupSlots := make(map[string]int)
 	for _, rrConf := range conf.RemoteReadConfigs {
 		hash, err := toHash(rrConf)
 		if err != nil {
@@ -127,23 +132,44 @@ func (s *Storage) ApplyConfig(conf *config.Config) error {
 			return err
 		}
 
-		externalLabels := conf.GlobalConfig.ExternalLabels
-		if !rrConf.FilterExternalLabels {
-			externalLabels = labels.EmptyLabels()
+		if group := rrConf.FailoverGroup; group != "" {
+			if _, ok := groupClients[group]; !ok {
+				groupConfs[group] = rrConf
+				groupSlots[group] = len(queryables)
+				queryables = append(queryables, nil) // Filled in below.
+			}
+			groupClients[group] = append(groupClients[group], c)
+			continue
 		}
-		queryables = append(queryables, NewSampleAndChunkQueryableClient(
-			c,
-			externalLabels,
-			labelsToEqualityMatchers(rrConf.RequiredMatchers),
-			rrConf.ReadRecent,
-			s.localStartTimeCallback,
-		))
+
+		queryables = append(queryables, s.newQueryable(conf, rrConf, c))
+	}
+	for group, clients := range groupClients {
+		rrConf := groupConfs[group]
+		c := NewFailoverReadClient(group, clients, time.Duration(rrConf.HedgeDelay), s.logger)
+		queryables[groupSlots[group]] = s.newQueryable(conf, rrConf, c)
 	}
 	s.queryables = queryables
 
 	return nil
 }
 
+// newQueryable builds the queryable for a single remote read endpoint or
+// failover group, applying the config's query-time options to the client.
+func (s *Storage) newQueryable(conf *config.Config, rrConf *config.RemoteReadConfig, c ReadClient) storage.SampleAndChunkQueryable {
+	externalLabels := conf.GlobalConfig.ExternalLabels
+	if !rrConf.FilterExternalLabels {
+		externalLabels = labels.EmptyLabels()
+	}
+	return NewSampleAndChunkQueryableClient(
+		c,
+		externalLabels,
+		labelsToEqualityMatchers(rrConf.RequiredMatchers),
+		rrConf.ReadRecent,
+		s.localStartTimeCallback,
+	)
+}
+
 // StartTime implements the Storage interface.
 func (*Storage) StartTime() (int64, error) {
 	return int64(model.Latest), nil
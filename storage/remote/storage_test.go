@@ -18,8 +18,10 @@ import (
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	common_config "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/prometheus/prometheus/config"
@@ -126,6 +128,43 @@ func TestIgnoreExternalLabels(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestApplyConfigFailoverGroups(t *testing.T) {
+	dir := t.TempDir()
+
+	s := NewStorage(nil, nil, nil, dir, defaultFlushDeadline, nil, false)
+
+	primary := baseRemoteReadConfig("http://primary.com")
+	primary.FailoverGroup = "pair"
+	primary.HedgeDelay = model.Duration(50 * time.Millisecond)
+	primary.ReadRecent = true
+	standalone := baseRemoteReadConfig("http://standalone.com")
+	replica := baseRemoteReadConfig("http://replica.com")
+	replica.FailoverGroup = "pair"
+
+	conf := &config.Config{
+		GlobalConfig:      config.DefaultGlobalConfig,
+		RemoteReadConfigs: []*config.RemoteReadConfig{primary, standalone, replica},
+	}
+	require.NoError(t, s.ApplyConfig(conf))
+
+	// Both group members collapse into one queryable in the slot of the
+	// first; the standalone endpoint keeps its own.
+	require.Len(t, s.queryables, 2)
+
+	grouped := s.queryables[0].(*sampleAndChunkQueryableClient)
+	fc, ok := grouped.client.(*failoverReadClient)
+	require.True(t, ok, "expected the grouped endpoints to share a failover client, got %T", grouped.client)
+	require.Len(t, fc.clients, 2)
+	require.Equal(t, 50*time.Millisecond, fc.hedgeDelay)
+	// Query-time options come from the group's first member.
+	require.True(t, grouped.readRecent)
+
+	_, ok = s.queryables[1].(*sampleAndChunkQueryableClient).client.(*Client)
+	require.True(t, ok, "expected the standalone endpoint to keep a plain read client")
+
+	require.NoError(t, s.Close())
+}
+
 // baseRemoteWriteConfig copy values from global Default Write config
 // to avoid change global state and cross impact test execution.
 func baseRemoteWriteConfig(host string) *config.RemoteWriteConfig {
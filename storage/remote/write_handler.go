@@ -145,13 +145,16 @@ func (h *writeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	enc := r.Header.Get("Content-Encoding")
-	if enc == "" {
+	enc := strings.ToLower(r.Header.Get("Content-Encoding"))
+	switch enc {
+	case "":
 		// Don't break yolo 1.0 clients if not needed. This is similar to what we did
 		// before 2.0: https://github.com/prometheus/prometheus/blob/d78253319daa62c8f28ed47e40bafcad2dd8b586/storage/remote/write_handler.go#L62
 		// We could give http.StatusUnsupportedMediaType, but let's assume snappy by default.
-	} else if strings.ToLower(enc) != compression.Snappy {
-		err := fmt.Errorf("%v encoding (compression) is not accepted by this server; only %v is acceptable", enc, compression.Snappy)
+		enc = compression.Snappy
+	case compression.Snappy, compression.Zstd:
+	default:
+		err := fmt.Errorf("%v encoding (compression) is not accepted by this server; only %v and %v are acceptable", enc, compression.Snappy, compression.Zstd)
 		h.logger.Error("Error decoding remote write request", "err", err)
 		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
 		return
@@ -165,7 +168,7 @@ func (h *writeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	decompressed, err := compression.Decode(compression.Snappy, body, nil)
+	decompressed, err := compression.Decode(enc, body, nil)
 	if err != nil {
 		// TODO(bwplotka): Add more context to responded error?
 		h.logger.Error("Error decompressing remote write request", "err", err.Error())
@@ -116,7 +116,7 @@ func TestRemoteWriteHandlerHeadersHandling_V1Message(t *testing.T) {
 			name: "not supported content-encoding",
 			reqHeaders: map[string]string{
 				"Content-Type":           remoteWriteContentTypeHeaders[config.RemoteWriteProtoMsgV1],
-				"Content-Encoding":       "zstd",
+				"Content-Encoding":       "gzip",
 				RemoteWriteVersionHeader: RemoteWriteVersion20HeaderValue,
 			},
 			expectedCode: http.StatusUnsupportedMediaType,
@@ -222,11 +222,11 @@ func TestRemoteWriteHandlerHeadersHandling_V2Message(t *testing.T) {
 			name: "not supported content-encoding",
 			reqHeaders: map[string]string{
 				"Content-Type":           remoteWriteContentTypeHeaders[config.RemoteWriteProtoMsgV2],
-				"Content-Encoding":       "zstd",
+				"Content-Encoding":       "gzip",
 				RemoteWriteVersionHeader: RemoteWriteVersion20HeaderValue,
 			},
 			expectedCode:  http.StatusUnsupportedMediaType,
-			expectedError: "zstd encoding (compression) is not accepted by this server; only snappy is acceptable",
+			expectedError: "gzip encoding (compression) is not accepted by this server; only snappy and zstd are acceptable",
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
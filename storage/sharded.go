@@ -0,0 +1,54 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// SelectSharded runs a Select as shardCount concurrent sub-selects, one per
+// series shard, and merges the resulting sets. Each sub-select gets a copy of
+// hints with the shard fields populated, so the storage only has to resolve
+// the postings of its shard. The storage backing q must support sharded
+// postings (for TSDB this means opening the head with EnableSharding).
+//
+// Sub-selects always request sorted series, as merging requires it. With
+// shardCount <= 1 this is a plain sorted Select.
+func SelectSharded(ctx context.Context, q Querier, hints *SelectHints, shardCount uint64, matchers ...*labels.Matcher) SeriesSet {
+	if shardCount <= 1 {
+		return q.Select(ctx, true, hints, matchers...)
+	}
+
+	sets := make([]SeriesSet, shardCount)
+	var wg sync.WaitGroup
+	for i := uint64(0); i < shardCount; i++ {
+		var shardHints SelectHints
+		if hints != nil {
+			shardHints = *hints
+		}
+		shardHints.ShardIndex, shardHints.ShardCount = i, shardCount
+
+		wg.Add(1)
+		go func(i uint64, hints *SelectHints) {
+			defer wg.Done()
+			sets[i] = q.Select(ctx, true, hints, matchers...)
+		}(i, &shardHints)
+	}
+	wg.Wait()
+
+	return NewMergeSeriesSet(sets, 0, ChainedSeriesMerge)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// shardedQuerier serves the given series filtered to the shard requested via
+// the Select hints, mimicking a TSDB with sharding enabled.
+type shardedQuerier struct {
+	LabelQuerier
+
+	mtx     sync.Mutex
+	selects []SelectHints
+	series  []Series
+}
+
+func (q *shardedQuerier) Select(_ context.Context, sortSeries bool, hints *SelectHints, _ ...*labels.Matcher) SeriesSet {
+	q.mtx.Lock()
+	if hints != nil {
+		q.selects = append(q.selects, *hints)
+	}
+	q.mtx.Unlock()
+
+	var ret []Series
+	for _, s := range q.series {
+		if hints != nil && hints.ShardCount > 0 && labels.StableHash(s.Labels())%hints.ShardCount != hints.ShardIndex {
+			continue
+		}
+		ret = append(ret, s)
+	}
+	if sortSeries {
+		sort.Sort(seriesByLabel(ret))
+	}
+	return &mockSeriesSet{idx: -1, series: ret}
+}
+
+func TestSelectSharded(t *testing.T) {
+	var series []Series
+	for i := 0; i < 10; i++ {
+		series = append(series, NewListSeries(labels.FromStrings("i", fmt.Sprintf("%02d", i)), nil))
+	}
+
+	for _, shardCount := range []uint64{0, 1, 2, 5} {
+		t.Run(fmt.Sprintf("shardCount=%d", shardCount), func(t *testing.T) {
+			q := &shardedQuerier{series: series}
+			ss := SelectSharded(context.Background(), q, &SelectHints{Start: 0, End: 100}, shardCount)
+
+			var got []labels.Labels
+			for ss.Next() {
+				got = append(got, ss.At().Labels())
+			}
+			require.NoError(t, ss.Err())
+
+			// All series come back exactly once, in label order.
+			require.Len(t, got, len(series))
+			for i, l := range got {
+				require.Equal(t, series[i].Labels(), l)
+			}
+
+			if shardCount > 1 {
+				require.Len(t, q.selects, int(shardCount))
+				// Sub-selects run concurrently, so shard indexes arrive in any order.
+				seen := map[uint64]struct{}{}
+				for _, h := range q.selects {
+					require.Equal(t, shardCount, h.ShardCount)
+					require.Equal(t, int64(0), h.Start)
+					require.Equal(t, int64(100), h.End)
+					seen[h.ShardIndex] = struct{}{}
+				}
+				require.Len(t, seen, int(shardCount))
+			}
+		})
+	}
+}
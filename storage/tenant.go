@@ -0,0 +1,140 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+// tenantQuerier scopes a querier to a single tenant identified by the value
+// of one label.
+type tenantQuerier struct {
+	q         Querier
+	matcher   *labels.Matcher
+	maxSeries int
+}
+
+// NewTenantQuerier returns a querier that only exposes the series of the
+// tenant identified by tenantLabel=tenantID. An equality matcher for the
+// tenant is injected into every Select and label lookup (replacing any
+// caller-supplied matcher on tenantLabel, so a tenant cannot select outside
+// its scope), and the tenant label is stripped from all results.
+//
+// If maxSeries is greater than zero, series sets returning more series than
+// that fail with an error during iteration.
+func NewTenantQuerier(q Querier, tenantLabel, tenantID string, maxSeries int) Querier {
+	m, err := labels.NewMatcher(labels.MatchEqual, tenantLabel, tenantID)
+	if err != nil {
+		// An equality matcher can't fail to compile.
+		panic(err)
+	}
+	return &tenantQuerier{q: q, matcher: m, maxSeries: maxSeries}
+}
+
+// withTenantMatcher replaces matchers on the tenant label with the tenant
+// matcher.
+func (q *tenantQuerier) withTenantMatcher(ms []*labels.Matcher) []*labels.Matcher {
+	out := make([]*labels.Matcher, 0, len(ms)+1)
+	for _, m := range ms {
+		if m.Name == q.matcher.Name {
+			continue
+		}
+		out = append(out, m)
+	}
+	return append(out, q.matcher)
+}
+
+func (q *tenantQuerier) Select(ctx context.Context, sortSeries bool, hints *SelectHints, matchers ...*labels.Matcher) SeriesSet {
+	set := q.q.Select(ctx, sortSeries, hints, q.withTenantMatcher(matchers)...)
+	return &tenantSeriesSet{
+		SeriesSet:   set,
+		tenantLabel: q.matcher.Name,
+		tenantID:    q.matcher.Value,
+		maxSeries:   q.maxSeries,
+	}
+}
+
+func (q *tenantQuerier) LabelValues(ctx context.Context, name string, hints *LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	if name == q.matcher.Name {
+		// The tenant label is stripped from all series this querier returns.
+		return nil, nil, nil
+	}
+	return q.q.LabelValues(ctx, name, hints, q.withTenantMatcher(matchers)...)
+}
+
+func (q *tenantQuerier) LabelNames(ctx context.Context, hints *LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	names, ws, err := q.q.LabelNames(ctx, hints, q.withTenantMatcher(matchers)...)
+	if err != nil {
+		return names, ws, err
+	}
+	// Copy to not modify the slice returned by the wrapped querier.
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != q.matcher.Name {
+			out = append(out, n)
+		}
+	}
+	return out, ws, nil
+}
+
+func (q *tenantQuerier) Close() error { return q.q.Close() }
+
+// tenantSeriesSet strips the tenant label from the series of the wrapped set
+// and enforces the per-tenant series limit.
+type tenantSeriesSet struct {
+	SeriesSet
+	tenantLabel string
+	tenantID    string
+	maxSeries   int
+
+	n   int
+	err error
+}
+
+func (s *tenantSeriesSet) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	if !s.SeriesSet.Next() {
+		return false
+	}
+	s.n++
+	if s.maxSeries > 0 && s.n > s.maxSeries {
+		s.err = fmt.Errorf("tenant %q exceeded the limit of %d series returned per select", s.tenantID, s.maxSeries)
+		return false
+	}
+	return true
+}
+
+func (s *tenantSeriesSet) At() Series {
+	series := s.SeriesSet.At()
+	return &SeriesEntry{
+		Lset: labels.NewBuilder(series.Labels()).Del(s.tenantLabel).Labels(),
+		SampleIteratorFn: func(it chunkenc.Iterator) chunkenc.Iterator {
+			return series.Iterator(it)
+		},
+	}
+}
+
+func (s *tenantSeriesSet) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.SeriesSet.Err()
+}
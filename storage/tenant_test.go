@@ -0,0 +1,117 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// matcherFilteringQuerier serves the given series, filtered by the Select
+// matchers, so tests can observe which matchers a wrapper injected.
+type matcherFilteringQuerier struct {
+	LabelQuerier
+	series []Series
+}
+
+func (q *matcherFilteringQuerier) Select(_ context.Context, _ bool, _ *SelectHints, matchers ...*labels.Matcher) SeriesSet {
+	var ret []Series
+Outer:
+	for _, s := range q.series {
+		for _, m := range matchers {
+			if !m.Matches(s.Labels().Get(m.Name)) {
+				continue Outer
+			}
+		}
+		ret = append(ret, s)
+	}
+	return &mockSeriesSet{idx: -1, series: ret}
+}
+
+func TestTenantQuerier_Select(t *testing.T) {
+	inner := &matcherFilteringQuerier{series: []Series{
+		NewListSeries(labels.FromStrings("__tenant__", "team-a", "instance", "1"), nil),
+		NewListSeries(labels.FromStrings("__tenant__", "team-a", "instance", "2"), nil),
+		NewListSeries(labels.FromStrings("__tenant__", "team-b", "instance", "1"), nil),
+	}}
+	q := NewTenantQuerier(inner, "__tenant__", "team-a", 0)
+
+	ss := q.Select(context.Background(), false, nil)
+	var got []labels.Labels
+	for ss.Next() {
+		got = append(got, ss.At().Labels())
+	}
+	require.NoError(t, ss.Err())
+	// Only team-a series come back and the tenant label is stripped.
+	require.Equal(t, []labels.Labels{
+		labels.FromStrings("instance", "1"),
+		labels.FromStrings("instance", "2"),
+	}, got)
+
+	// A caller-supplied matcher on the tenant label cannot widen the scope.
+	ss = q.Select(context.Background(), false, nil, labels.MustNewMatcher(labels.MatchEqual, "__tenant__", "team-b"))
+	got = got[:0]
+	for ss.Next() {
+		got = append(got, ss.At().Labels())
+	}
+	require.NoError(t, ss.Err())
+	require.Len(t, got, 2)
+}
+
+func TestTenantQuerier_MaxSeries(t *testing.T) {
+	inner := &matcherFilteringQuerier{series: []Series{
+		NewListSeries(labels.FromStrings("__tenant__", "team-a", "instance", "1"), nil),
+		NewListSeries(labels.FromStrings("__tenant__", "team-a", "instance", "2"), nil),
+		NewListSeries(labels.FromStrings("__tenant__", "team-a", "instance", "3"), nil),
+	}}
+	q := NewTenantQuerier(inner, "__tenant__", "team-a", 2)
+
+	ss := q.Select(context.Background(), false, nil)
+	n := 0
+	for ss.Next() {
+		n++
+	}
+	require.Equal(t, 2, n)
+	require.ErrorContains(t, ss.Err(), "exceeded the limit of 2 series")
+}
+
+func TestTenantQuerier_LabelNamesAndValues(t *testing.T) {
+	inner := &mockQuerier{resp: []string{"__tenant__", "instance", "job"}}
+	q := NewTenantQuerier(inner, "__tenant__", "team-a", 0)
+
+	names, _, err := q.LabelNames(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"instance", "job"}, names)
+
+	// The tenant label is hidden entirely.
+	values, _, err := q.LabelValues(context.Background(), "__tenant__", nil)
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	values, _, err = q.LabelValues(context.Background(), "job", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"__tenant__", "instance", "job"}, values)
+	// The tenant matcher is injected into the lookup.
+	require.Len(t, inner.labelNamesRequested, 1)
+	ms := inner.labelNamesRequested[0].matchers
+	require.Len(t, ms, 1)
+	require.Equal(t, `__tenant__="team-a"`, ms[0].String())
+
+	require.NoError(t, q.Close())
+	require.True(t, inner.closed)
+}
@@ -220,6 +220,12 @@ type Options struct {
 	// By default, DefaultPostingsDecoderFactory will be used to create raw posting decoder.
 	PostingsDecoderFactory PostingsDecoderFactory
 
+	// ExpandedPostingsCache, if set, caches expanded postings per persistent
+	// block and matcher set in the default block queriers. Entries of blocks
+	// deleted after compaction are evicted from it. It is ignored when
+	// BlockQuerierFunc/BlockChunkQuerierFunc are set.
+	ExpandedPostingsCache ExpandedPostingsCache
+
 	// UseUncachedIO allows bypassing the page cache when appropriate.
 	UseUncachedIO bool
 }
@@ -915,16 +921,33 @@ func open(dir string, l *slog.Logger, r prometheus.Registerer, opts *Options, rn
 	}
 	db.compactCancel = cancel
 
-	if opts.BlockQuerierFunc == nil {
-		db.blockQuerierFunc = NewBlockQuerier
-	} else {
+	switch {
+	case opts.BlockQuerierFunc != nil:
 		db.blockQuerierFunc = opts.BlockQuerierFunc
+	case opts.ExpandedPostingsCache != nil:
+		db.blockQuerierFunc = func(b BlockReader, mint, maxt int64) (storage.Querier, error) {
+			if _, ok := b.(*Block); ok {
+				return NewBlockQuerierWithCache(b, mint, maxt, opts.ExpandedPostingsCache)
+			}
+			// The head is mutable, its postings can't be cached.
+			return NewBlockQuerier(b, mint, maxt)
+		}
+	default:
+		db.blockQuerierFunc = NewBlockQuerier
 	}
 
-	if opts.BlockChunkQuerierFunc == nil {
-		db.blockChunkQuerierFunc = NewBlockChunkQuerier
-	} else {
+	switch {
+	case opts.BlockChunkQuerierFunc != nil:
 		db.blockChunkQuerierFunc = opts.BlockChunkQuerierFunc
+	case opts.ExpandedPostingsCache != nil:
+		db.blockChunkQuerierFunc = func(b BlockReader, mint, maxt int64) (storage.ChunkQuerier, error) {
+			if _, ok := b.(*Block); ok {
+				return NewBlockChunkQuerierWithCache(b, mint, maxt, opts.ExpandedPostingsCache)
+			}
+			return NewBlockChunkQuerier(b, mint, maxt)
+		}
+	default:
+		db.blockChunkQuerierFunc = NewBlockChunkQuerier
 	}
 
 	var wal, wbl *wlog.WL
@@ -1804,6 +1827,9 @@ func (db *DB) deleteBlocks(blocks map[ulid.ULID]*Block) error {
 				db.logger.Warn("Closing block failed", "err", err, "block", ulid)
 			}
 		}
+		if db.opts.ExpandedPostingsCache != nil {
+			db.opts.ExpandedPostingsCache.Evict(ulid)
+		}
 
 		toDelete := filepath.Join(db.dir, ulid.String())
 		switch _, err := os.Stat(toDelete); {
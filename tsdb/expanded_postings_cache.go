@@ -0,0 +1,80 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsdb
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/index"
+)
+
+// ExpandedPostingsCache caches the result of expanding the postings for a set
+// of matchers against a persistent block. It lets embedders plug an
+// in-process or external cache into the block queriers without wrapping them.
+//
+// Implementations must be safe for concurrent use and are responsible for
+// bounding their own size. The DB evicts the entries of blocks it deletes
+// after compaction, so implementations don't have to track block lifetimes
+// themselves. Postings of the head are never cached as the head is mutable.
+type ExpandedPostingsCache interface {
+	// Get returns the expanded postings cached under the given block and key,
+	// if present. The caller must not modify the returned slice.
+	Get(blockID ulid.ULID, key string) ([]storage.SeriesRef, bool)
+	// Put stores the expanded postings under the given block and key.
+	Put(blockID ulid.ULID, key string, refs []storage.SeriesRef)
+	// Evict drops all entries of the given block.
+	Evict(blockID ulid.ULID)
+}
+
+// ExpandedPostingsCacheKey returns the cache key under which the expanded
+// postings for ms are stored. The key is independent of the matchers' order.
+func ExpandedPostingsCacheKey(ms []*labels.Matcher) string {
+	keys := make([]string, len(ms))
+	for i, m := range ms {
+		keys[i] = m.String()
+	}
+	slices.Sort(keys)
+	return strings.Join(keys, "\xff")
+}
+
+// cachedPostingsForMatchers is PostingsForMatchers backed by cache. On a miss
+// the assembled postings are expanded and stored, so it must only be used
+// against immutable blocks. A nil cache degrades to plain PostingsForMatchers.
+func cachedPostingsForMatchers(ctx context.Context, ix IndexReader, blockID ulid.ULID, cache ExpandedPostingsCache, ms []*labels.Matcher) (index.Postings, error) {
+	if cache == nil {
+		return PostingsForMatchers(ctx, ix, ms...)
+	}
+
+	key := ExpandedPostingsCacheKey(ms)
+	if refs, ok := cache.Get(blockID, key); ok {
+		return index.NewListPostings(refs), nil
+	}
+
+	p, err := PostingsForMatchers(ctx, ix, ms...)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := index.ExpandPostings(p)
+	if err != nil {
+		return nil, err
+	}
+	cache.Put(blockID, key, refs)
+	return index.NewListPostings(refs), nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsdb
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+)
+
+// mapPostingsCache is an unbounded ExpandedPostingsCache for tests.
+type mapPostingsCache struct {
+	mtx          sync.Mutex
+	entries      map[ulid.ULID]map[string][]storage.SeriesRef
+	hits, misses int
+}
+
+func newMapPostingsCache() *mapPostingsCache {
+	return &mapPostingsCache{entries: map[ulid.ULID]map[string][]storage.SeriesRef{}}
+}
+
+func (c *mapPostingsCache) Get(blockID ulid.ULID, key string) ([]storage.SeriesRef, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	refs, ok := c.entries[blockID][key]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return refs, ok
+}
+
+func (c *mapPostingsCache) Put(blockID ulid.ULID, key string, refs []storage.SeriesRef) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.entries[blockID] == nil {
+		c.entries[blockID] = map[string][]storage.SeriesRef{}
+	}
+	c.entries[blockID][key] = refs
+}
+
+func (c *mapPostingsCache) Evict(blockID ulid.ULID) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.entries, blockID)
+}
+
+func TestExpandedPostingsCacheKey(t *testing.T) {
+	m1 := labels.MustNewMatcher(labels.MatchEqual, "a", "a")
+	m2 := labels.MustNewMatcher(labels.MatchRegexp, "b", "b.*")
+
+	// The key is independent of the matchers' order.
+	require.Equal(t,
+		ExpandedPostingsCacheKey([]*labels.Matcher{m1, m2}),
+		ExpandedPostingsCacheKey([]*labels.Matcher{m2, m1}))
+	require.NotEqual(t,
+		ExpandedPostingsCacheKey([]*labels.Matcher{m1}),
+		ExpandedPostingsCacheKey([]*labels.Matcher{m2}))
+}
+
+func TestDBWithExpandedPostingsCache(t *testing.T) {
+	cache := newMapPostingsCache()
+	opts := DefaultOptions()
+	opts.ExpandedPostingsCache = cache
+	db := openTestDB(t, opts, []int64{1000})
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	ctx := context.Background()
+	app := db.Appender(ctx)
+	for i := int64(0); i < 10; i++ {
+		_, err := app.Append(0, labels.FromStrings("foo", "bar"), i*1000, float64(i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, app.Commit())
+
+	queryAll := func() map[string][]chunks.Sample {
+		q, err := db.Querier(0, 10000)
+		require.NoError(t, err)
+		return query(t, q, labels.MustNewMatcher(labels.MatchEqual, "foo", "bar"))
+	}
+
+	// Head-only queries must not touch the cache.
+	res := queryAll()
+	require.Len(t, res, 1)
+	require.Zero(t, cache.hits+cache.misses)
+
+	// After compacting the head into a persistent block, the first query
+	// misses and fills the cache, the second one hits.
+	require.NoError(t, db.Compact(ctx))
+	nBlocks := len(db.Blocks())
+	require.NotZero(t, nBlocks)
+
+	before := queryAll()
+	require.Equal(t, nBlocks, cache.misses)
+	require.Zero(t, cache.hits)
+
+	after := queryAll()
+	require.Equal(t, nBlocks, cache.misses)
+	require.Equal(t, nBlocks, cache.hits)
+	require.Equal(t, before, after)
+
+	// Deleting blocks evicts their cache entries.
+	blockID := db.Blocks()[0].Meta().ULID
+	require.Contains(t, cache.entries, blockID)
+	require.NoError(t, db.deleteBlocks(map[ulid.ULID]*Block{blockID: nil}))
+	require.NotContains(t, cache.entries, blockID)
+}
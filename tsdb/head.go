@@ -22,6 +22,7 @@ import (
 	"math"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -2062,6 +2063,54 @@ func (s *stripeSeries) getByID(id chunks.HeadSeriesRef) *memSeries {
 	return series
 }
 
+// getByIDs resolves many series references in one call, acquiring each
+// stripe lock at most once instead of once per id. The returned slice is
+// aligned with ids; ids that don't resolve to a series yield a nil entry.
+// buf, if non-nil, is reused for the result.
+func (s *stripeSeries) getByIDs(ids []chunks.HeadSeriesRef, buf []*memSeries) []*memSeries {
+	if cap(buf) < len(ids) {
+		buf = make([]*memSeries, len(ids))
+	} else {
+		buf = buf[:len(ids)]
+		clear(buf)
+	}
+
+	// Visit ids grouped by stripe so that each stripe lock is taken at most
+	// once, while keeping the result aligned with the input order.
+	order := make([]int, len(ids))
+	for i := range order {
+		order[i] = i
+	}
+	stripe := func(idx int) uint64 { return uint64(ids[idx]) & uint64(s.size-1) }
+	slices.SortFunc(order, func(a, b int) int {
+		switch sa, sb := stripe(a), stripe(b); {
+		case sa < sb:
+			return -1
+		case sa > sb:
+			return +1
+		default:
+			return 0
+		}
+	})
+
+	locked := uint64(math.MaxUint64)
+	for _, idx := range order {
+		if i := stripe(idx); i != locked {
+			if locked != math.MaxUint64 {
+				s.locks[locked].RUnlock()
+			}
+			s.locks[i].RLock()
+			locked = i
+		}
+		buf[idx] = s.series[locked][ids[idx]]
+	}
+	if locked != math.MaxUint64 {
+		s.locks[locked].RUnlock()
+	}
+
+	return buf
+}
+
 func (s *stripeSeries) getByHash(hash uint64, lset labels.Labels) *memSeries {
 	i := hash & uint64(s.size-1)
 
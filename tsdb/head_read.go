@@ -201,6 +201,33 @@ func (h *headIndexReader) Series(ref storage.SeriesRef, builder *labels.ScratchB
 	return nil
 }
 
+// SeriesBatch implements SeriesBatchReader. It resolves all refs with a
+// single pass over the head's series stripes instead of re-acquiring the
+// stripe locks once per ref as Series does.
+func (h *headIndexReader) SeriesBatch(refs []storage.SeriesRef, builder *labels.ScratchBuilder, chks *[]chunks.Meta, f func(ref storage.SeriesRef, builder *labels.ScratchBuilder, chks []chunks.Meta) error) error {
+	ids := make([]chunks.HeadSeriesRef, len(refs))
+	for i, ref := range refs {
+		ids[i] = chunks.HeadSeriesRef(ref)
+	}
+
+	for i, s := range h.head.series.getByIDs(ids, nil) {
+		if s == nil {
+			h.head.metrics.seriesNotFound.Inc()
+			continue
+		}
+		builder.Assign(s.labels())
+
+		s.Lock()
+		*chks = appendSeriesChunks(s, h.mint, h.maxt, (*chks)[:0])
+		s.Unlock()
+
+		if err := f(refs[i], builder, *chks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func appendSeriesChunks(s *memSeries, mint, maxt int64, chks []chunks.Meta) []chunks.Meta {
 	for i, c := range s.mmappedChunks {
 		// Do not expose chunks that are outside of the specified range.
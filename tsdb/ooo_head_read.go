@@ -83,6 +83,24 @@ func (oh *HeadAndOOOIndexReader) Series(ref storage.SeriesRef, builder *labels.S
 	return nil
 }
 
+// SeriesBatch overrides the embedded headIndexReader's implementation, which
+// wouldn't include the OOO chunks. Refs are resolved with per-ref Series
+// calls instead.
+func (oh *HeadAndOOOIndexReader) SeriesBatch(refs []storage.SeriesRef, builder *labels.ScratchBuilder, chks *[]chunks.Meta, f func(ref storage.SeriesRef, builder *labels.ScratchBuilder, chks []chunks.Meta) error) error {
+	for _, ref := range refs {
+		if err := oh.Series(ref, builder, chks); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if err := f(ref, builder, *chks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // lastGarbageCollectedMmapRef gives the last mmap chunk that may be being garbage collected and so
 // any chunk at or before this ref will not be considered. 0 disables this check.
 //
@@ -561,7 +579,7 @@ func (q *HeadAndOOOQuerier) Close() error {
 }
 
 func (q *HeadAndOOOQuerier) Select(ctx context.Context, sortSeries bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
-	return selectSeriesSet(ctx, sortSeries, hints, matchers, q.index, q.chunkr, q.head.tombstones, q.mint, q.maxt)
+	return selectSeriesSet(ctx, sortSeries, hints, matchers, rangeHeadULID, q.index, q.chunkr, q.head.tombstones, nil, q.mint, q.maxt)
 }
 
 // HeadAndOOOChunkQuerier queries both the head and the out-of-order head.
@@ -613,5 +631,5 @@ func (q *HeadAndOOOChunkQuerier) Close() error {
 }
 
 func (q *HeadAndOOOChunkQuerier) Select(ctx context.Context, sortSeries bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.ChunkSeriesSet {
-	return selectChunkSeriesSet(ctx, sortSeries, hints, matchers, rangeHeadULID, q.index, q.chunkr, q.head.tombstones, q.mint, q.maxt)
+	return selectChunkSeriesSet(ctx, sortSeries, hints, matchers, rangeHeadULID, q.index, q.chunkr, q.head.tombstones, nil, q.mint, q.maxt)
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"time"
 
 	"github.com/oklog/ulid/v2"
 
@@ -42,6 +43,10 @@ type blockBaseQuerier struct {
 	chunks     ChunkReader
 	tombstones tombstones.Reader
 
+	// postingsCache, if non-nil, caches expanded postings per matcher set.
+	// Only set for immutable blocks.
+	postingsCache ExpandedPostingsCache
+
 	closed bool
 
 	mint, maxt int64
@@ -114,17 +119,33 @@ func NewBlockQuerier(b BlockReader, mint, maxt int64) (storage.Querier, error) {
 	return &blockQuerier{blockBaseQuerier: q}, nil
 }
 
+// NewBlockQuerierWithCache is like NewBlockQuerier, additionally consulting
+// cache for expanded postings. It must only be used against immutable blocks.
+func NewBlockQuerierWithCache(b BlockReader, mint, maxt int64, cache ExpandedPostingsCache) (storage.Querier, error) {
+	q, err := newBlockBaseQuerier(b, mint, maxt)
+	if err != nil {
+		return nil, err
+	}
+	q.postingsCache = cache
+	return &blockQuerier{blockBaseQuerier: q}, nil
+}
+
 func (q *blockQuerier) Select(ctx context.Context, sortSeries bool, hints *storage.SelectHints, ms ...*labels.Matcher) storage.SeriesSet {
-	return selectSeriesSet(ctx, sortSeries, hints, ms, q.index, q.chunks, q.tombstones, q.mint, q.maxt)
+	return selectSeriesSet(ctx, sortSeries, hints, ms, q.blockID, q.index, q.chunks, q.tombstones, q.postingsCache, q.mint, q.maxt)
 }
 
 func selectSeriesSet(ctx context.Context, sortSeries bool, hints *storage.SelectHints, ms []*labels.Matcher,
-	index IndexReader, chunks ChunkReader, tombstones tombstones.Reader, mint, maxt int64,
+	blockID ulid.ULID, index IndexReader, chunks ChunkReader, tombstones tombstones.Reader, cache ExpandedPostingsCache, mint, maxt int64,
 ) storage.SeriesSet {
 	disableTrimming := false
 	sharded := hints != nil && hints.ShardCount > 0
+	stats := SelectStatsFromContext(ctx)
 
-	p, err := PostingsForMatchers(ctx, index, ms...)
+	var indexStart time.Time
+	if stats != nil {
+		indexStart = time.Now()
+	}
+	p, err := cachedPostingsForMatchers(ctx, index, blockID, cache, ms)
 	if err != nil {
 		return storage.ErrSeriesSet(err)
 	}
@@ -134,6 +155,9 @@ func selectSeriesSet(ctx context.Context, sortSeries bool, hints *storage.Select
 	if sortSeries {
 		p = index.SortedPostings(p)
 	}
+	if stats != nil {
+		stats.IndexTime.Add(time.Since(indexStart))
+	}
 
 	if hints != nil {
 		mint = hints.Start
@@ -141,11 +165,11 @@ func selectSeriesSet(ctx context.Context, sortSeries bool, hints *storage.Select
 		disableTrimming = hints.DisableTrimming
 		if hints.Func == "series" {
 			// When you're only looking up metadata (for example series API), you don't need to load any chunks.
-			return newBlockSeriesSet(index, newNopChunkReader(), tombstones, p, mint, maxt, disableTrimming)
+			return newBlockSeriesSet(index, newNopChunkReader(), tombstones, p, mint, maxt, disableTrimming, stats)
 		}
 	}
 
-	return newBlockSeriesSet(index, chunks, tombstones, p, mint, maxt, disableTrimming)
+	return newBlockSeriesSet(index, chunks, tombstones, p, mint, maxt, disableTrimming, stats)
 }
 
 // blockChunkQuerier provides chunk querying access to a single block database.
@@ -162,22 +186,39 @@ func NewBlockChunkQuerier(b BlockReader, mint, maxt int64) (storage.ChunkQuerier
 	return &blockChunkQuerier{blockBaseQuerier: q}, nil
 }
 
+// NewBlockChunkQuerierWithCache is like NewBlockChunkQuerier, additionally
+// consulting cache for expanded postings. It must only be used against
+// immutable blocks.
+func NewBlockChunkQuerierWithCache(b BlockReader, mint, maxt int64, cache ExpandedPostingsCache) (storage.ChunkQuerier, error) {
+	q, err := newBlockBaseQuerier(b, mint, maxt)
+	if err != nil {
+		return nil, err
+	}
+	q.postingsCache = cache
+	return &blockChunkQuerier{blockBaseQuerier: q}, nil
+}
+
 func (q *blockChunkQuerier) Select(ctx context.Context, sortSeries bool, hints *storage.SelectHints, ms ...*labels.Matcher) storage.ChunkSeriesSet {
-	return selectChunkSeriesSet(ctx, sortSeries, hints, ms, q.blockID, q.index, q.chunks, q.tombstones, q.mint, q.maxt)
+	return selectChunkSeriesSet(ctx, sortSeries, hints, ms, q.blockID, q.index, q.chunks, q.tombstones, q.postingsCache, q.mint, q.maxt)
 }
 
 func selectChunkSeriesSet(ctx context.Context, sortSeries bool, hints *storage.SelectHints, ms []*labels.Matcher,
-	blockID ulid.ULID, index IndexReader, chunks ChunkReader, tombstones tombstones.Reader, mint, maxt int64,
+	blockID ulid.ULID, index IndexReader, chunks ChunkReader, tombstones tombstones.Reader, cache ExpandedPostingsCache, mint, maxt int64,
 ) storage.ChunkSeriesSet {
 	disableTrimming := false
 	sharded := hints != nil && hints.ShardCount > 0
+	stats := SelectStatsFromContext(ctx)
 
 	if hints != nil {
 		mint = hints.Start
 		maxt = hints.End
 		disableTrimming = hints.DisableTrimming
 	}
-	p, err := PostingsForMatchers(ctx, index, ms...)
+	var indexStart time.Time
+	if stats != nil {
+		indexStart = time.Now()
+	}
+	p, err := cachedPostingsForMatchers(ctx, index, blockID, cache, ms)
 	if err != nil {
 		return storage.ErrChunkSeriesSet(err)
 	}
@@ -187,13 +228,21 @@ func selectChunkSeriesSet(ctx context.Context, sortSeries bool, hints *storage.S
 	if sortSeries {
 		p = index.SortedPostings(p)
 	}
-	return NewBlockChunkSeriesSet(blockID, index, chunks, tombstones, p, mint, maxt, disableTrimming)
+	if stats != nil {
+		stats.IndexTime.Add(time.Since(indexStart))
+	}
+	return newBlockChunkSeriesSet(blockID, index, chunks, tombstones, p, mint, maxt, disableTrimming, stats)
 }
 
 // PostingsForMatchers assembles a single postings iterator against the index reader
 // based on the given matchers. The resulting postings are not ordered by series.
 func PostingsForMatchers(ctx context.Context, ix IndexReader, ms ...*labels.Matcher) (index.Postings, error) {
+	stats := SelectStatsFromContext(ctx)
+
 	if len(ms) == 1 && ms[0].Name == "" && ms[0].Value == "" {
+		if stats != nil {
+			stats.PostingsTouched.Inc()
+		}
 		k, v := index.AllPostingsKey()
 		return ix.Postings(ctx, k, v)
 	}
@@ -331,6 +380,10 @@ func PostingsForMatchers(ctx context.Context, ix IndexReader, ms ...*labels.Matc
 		}
 	}
 
+	if stats != nil {
+		stats.PostingsTouched.Add(int64(len(its) + len(notIts)))
+	}
+
 	it := index.Intersect(its...)
 
 	for _, n := range notIts {
@@ -492,33 +545,73 @@ type blockBaseSeriesSet struct {
 	tombstones      tombstones.Reader
 	mint, maxt      int64
 	disableTrimming bool
+	stats           *SelectStats
 
 	curr seriesData
 
+	// Series are resolved from postings seriesBatchSize refs at a time via
+	// SeriesBatch, so index readers that support it (notably the head) can
+	// amortize lock acquisitions over the batch.
+	refs       []storage.SeriesRef
+	pending    []seriesData
+	pendingIdx int
+
 	bufChks []chunks.Meta
 	builder labels.ScratchBuilder
 	err     error
 }
 
+// seriesBatchSize is the number of postings resolved per SeriesBatch call in
+// blockBaseSeriesSet.
+const seriesBatchSize = 128
+
 func (b *blockBaseSeriesSet) Next() bool {
-	for b.p.Next() {
-		if err := b.index.Series(b.p.At(), &b.builder, &b.bufChks); err != nil {
-			// Postings may be stale. Skip if no underlying series exists.
-			if errors.Is(err, storage.ErrNotFound) {
-				continue
-			}
-			b.err = fmt.Errorf("get series %d: %w", b.p.At(), err)
+	for {
+		if b.pendingIdx < len(b.pending) {
+			b.curr = b.pending[b.pendingIdx]
+			b.pendingIdx++
+			return true
+		}
+		if !b.nextBatch() {
 			return false
 		}
+	}
+}
+
+// nextBatch pulls up to seriesBatchSize refs from the postings iterator and
+// resolves them in one SeriesBatch call, filling b.pending with the series
+// that have chunks within the requested range. It returns false once the
+// postings are exhausted or on error.
+func (b *blockBaseSeriesSet) nextBatch() bool {
+	if b.err != nil {
+		return false
+	}
+	b.refs = b.refs[:0]
+	for len(b.refs) < seriesBatchSize && b.p.Next() {
+		b.refs = append(b.refs, b.p.At())
+	}
+	if len(b.refs) == 0 {
+		return false
+	}
+	b.pending = b.pending[:0]
+	b.pendingIdx = 0
+
+	var indexStart time.Time
+	if b.stats != nil {
+		indexStart = time.Now()
+	}
+	err := SeriesBatch(b.index, b.refs, &b.builder, &b.bufChks, func(ref storage.SeriesRef, builder *labels.ScratchBuilder, bufChks []chunks.Meta) error {
+		if b.stats != nil {
+			b.stats.SeriesExamined.Inc()
+		}
 
-		if len(b.bufChks) == 0 {
-			continue
+		if len(bufChks) == 0 {
+			return nil
 		}
 
-		intervals, err := b.tombstones.Get(b.p.At())
+		intervals, err := b.tombstones.Get(ref)
 		if err != nil {
-			b.err = fmt.Errorf("get tombstones: %w", err)
-			return false
+			return fmt.Errorf("get tombstones for series %d: %w", ref, err)
 		}
 
 		// NOTE:
@@ -531,7 +624,7 @@ func (b *blockBaseSeriesSet) Next() bool {
 		// Copy chunks as iterables are reusable.
 		// Count those in range to size allocation (roughly - ignoring tombstones).
 		nChks := 0
-		for _, chk := range b.bufChks {
+		for _, chk := range bufChks {
 			if chk.MaxTime >= b.mint && chk.MinTime <= b.maxt {
 				nChks++
 			}
@@ -539,7 +632,7 @@ func (b *blockBaseSeriesSet) Next() bool {
 		chks := make([]chunks.Meta, 0, nChks)
 
 		// Prefilter chunks and pick those which are not entirely deleted or totally outside of the requested range.
-		for _, chk := range b.bufChks {
+		for _, chk := range bufChks {
 			if chk.MaxTime < b.mint {
 				continue
 			}
@@ -563,7 +656,7 @@ func (b *blockBaseSeriesSet) Next() bool {
 		}
 
 		if len(chks) == 0 {
-			continue
+			return nil
 		}
 
 		if trimFront {
@@ -573,12 +666,27 @@ func (b *blockBaseSeriesSet) Next() bool {
 			intervals = intervals.Add(tombstones.Interval{Mint: b.maxt + 1, Maxt: math.MaxInt64})
 		}
 
-		b.curr.labels = b.builder.Labels()
-		b.curr.chks = chks
-		b.curr.intervals = intervals
-		return true
+		if b.stats != nil {
+			b.stats.SeriesMatched.Inc()
+			b.stats.ChunksTouched.Add(int64(len(chks)))
+		}
+
+		b.pending = append(b.pending, seriesData{
+			labels:    builder.Labels(),
+			chks:      chks,
+			intervals: intervals,
+		})
+		return nil
+	})
+	if b.stats != nil {
+		b.stats.IndexTime.Add(time.Since(indexStart))
 	}
-	return false
+	if err != nil {
+		// SeriesBatch and the callback name the failing series themselves.
+		b.err = err
+		return false
+	}
+	return true
 }
 
 func (b *blockBaseSeriesSet) Err() error {
@@ -620,6 +728,10 @@ type populateWithDelGenericSeriesIterator struct {
 	// the chunk returned from cr.ChunkOrIterable(). As that can return a nil
 	// chunk, currMeta.Chunk is not always guaranteed to be set.
 	currMeta chunks.Meta
+
+	// stats, if non-nil, counts the decoded chunks and samples and the time
+	// spent reading chunk data.
+	stats *SelectStats
 }
 
 func (p *populateWithDelGenericSeriesIterator) reset(blockID ulid.ULID, cr ChunkReader, chks []chunks.Meta, intervals tombstones.Intervals) {
@@ -654,6 +766,10 @@ func (p *populateWithDelGenericSeriesIterator) next(copyHeadChunk bool) bool {
 		}
 	}
 
+	var chunkStart time.Time
+	if p.stats != nil {
+		chunkStart = time.Now()
+	}
 	hcr, ok := p.cr.(ChunkReaderWithCopy)
 	var iterable chunkenc.Iterable
 	if ok && copyHeadChunk && len(p.bufIter.Intervals) == 0 {
@@ -672,6 +788,13 @@ func (p *populateWithDelGenericSeriesIterator) next(copyHeadChunk bool) bool {
 		p.err = fmt.Errorf("cannot populate chunk %d from block %s: %w", p.currMeta.Ref, p.blockID.String(), p.err)
 		return false
 	}
+	if p.stats != nil {
+		p.stats.ChunkTime.Add(time.Since(chunkStart))
+		p.stats.ChunksDecoded.Inc()
+		if p.currMeta.Chunk != nil {
+			p.stats.SamplesDecoded.Add(int64(p.currMeta.Chunk.NumSamples()))
+		}
+	}
 
 	// Use the single chunk if possible.
 	if p.currMeta.Chunk != nil {
@@ -699,6 +822,7 @@ func (p *populateWithDelGenericSeriesIterator) Err() error { return p.err }
 type blockSeriesEntry struct {
 	chunks  ChunkReader
 	blockID ulid.ULID
+	stats   *SelectStats
 	seriesData
 }
 
@@ -708,12 +832,14 @@ func (s *blockSeriesEntry) Iterator(it chunkenc.Iterator) chunkenc.Iterator {
 		pi = &populateWithDelSeriesIterator{}
 	}
 	pi.reset(s.blockID, s.chunks, s.chks, s.intervals)
+	pi.stats = s.stats
 	return pi
 }
 
 type chunkSeriesEntry struct {
 	chunks  ChunkReader
 	blockID ulid.ULID
+	stats   *SelectStats
 	seriesData
 }
 
@@ -723,6 +849,7 @@ func (s *chunkSeriesEntry) Iterator(it chunks.Iterator) chunks.Iterator {
 		pi = &populateWithDelChunkSeriesIterator{}
 	}
 	pi.reset(s.blockID, s.chunks, s.chks, s.intervals)
+	pi.stats = s.stats
 	return pi
 }
 
@@ -1071,7 +1198,7 @@ type blockSeriesSet struct {
 	blockBaseSeriesSet
 }
 
-func newBlockSeriesSet(i IndexReader, c ChunkReader, t tombstones.Reader, p index.Postings, mint, maxt int64, disableTrimming bool) storage.SeriesSet {
+func newBlockSeriesSet(i IndexReader, c ChunkReader, t tombstones.Reader, p index.Postings, mint, maxt int64, disableTrimming bool, stats *SelectStats) storage.SeriesSet {
 	return &blockSeriesSet{
 		blockBaseSeriesSet{
 			index:           i,
@@ -1081,6 +1208,7 @@ func newBlockSeriesSet(i IndexReader, c ChunkReader, t tombstones.Reader, p inde
 			mint:            mint,
 			maxt:            maxt,
 			disableTrimming: disableTrimming,
+			stats:           stats,
 		},
 	}
 }
@@ -1090,6 +1218,7 @@ func (b *blockSeriesSet) At() storage.Series {
 	return &blockSeriesEntry{
 		chunks:     b.chunks,
 		blockID:    b.blockID,
+		stats:      b.stats,
 		seriesData: b.curr,
 	}
 }
@@ -1102,6 +1231,10 @@ type blockChunkSeriesSet struct {
 }
 
 func NewBlockChunkSeriesSet(id ulid.ULID, i IndexReader, c ChunkReader, t tombstones.Reader, p index.Postings, mint, maxt int64, disableTrimming bool) storage.ChunkSeriesSet {
+	return newBlockChunkSeriesSet(id, i, c, t, p, mint, maxt, disableTrimming, nil)
+}
+
+func newBlockChunkSeriesSet(id ulid.ULID, i IndexReader, c ChunkReader, t tombstones.Reader, p index.Postings, mint, maxt int64, disableTrimming bool, stats *SelectStats) storage.ChunkSeriesSet {
 	return &blockChunkSeriesSet{
 		blockBaseSeriesSet{
 			blockID:         id,
@@ -1112,6 +1245,7 @@ func NewBlockChunkSeriesSet(id ulid.ULID, i IndexReader, c ChunkReader, t tombst
 			mint:            mint,
 			maxt:            maxt,
 			disableTrimming: disableTrimming,
+			stats:           stats,
 		},
 	}
 }
@@ -1121,6 +1255,7 @@ func (b *blockChunkSeriesSet) At() storage.ChunkSeries {
 	return &chunkSeriesEntry{
 		chunks:     b.chunks,
 		blockID:    b.blockID,
+		stats:      b.stats,
 		seriesData: b.curr,
 	}
 }
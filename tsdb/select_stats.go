@@ -0,0 +1,80 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsdb
+
+import (
+	"context"
+
+	"go.uber.org/atomic"
+)
+
+// SelectStats counts the work done by Select calls issued with a context
+// prepared via ContextWithSelectStats. A single stats object can be shared by
+// the Select calls of all block and head queriers serving one query, so the
+// counters are atomic. The zero value is ready to use.
+type SelectStats struct {
+	// PostingsTouched is the number of postings lists resolved from the index
+	// while converting matchers to postings.
+	PostingsTouched atomic.Int64
+	// SeriesExamined is the number of series whose metadata (labels and chunk
+	// metas) was read from the index.
+	SeriesExamined atomic.Int64
+	// SeriesMatched is the number of series returned after discarding series
+	// with no chunks in the requested time range.
+	SeriesMatched atomic.Int64
+	// ChunksTouched is the number of chunk metas selected for reading after
+	// time-range and tombstone filtering.
+	ChunksTouched atomic.Int64
+	// ChunksDecoded is the number of chunks opened from the chunk reader
+	// during iteration.
+	ChunksDecoded atomic.Int64
+	// SamplesDecoded is the number of samples in the decoded chunks. Chunks
+	// served as lazy iterables (e.g. out-of-order head chunks) count towards
+	// ChunksDecoded, but their sample count is unknown up front and not
+	// included here.
+	SamplesDecoded atomic.Int64
+	// IndexTime is the time spent resolving matchers to postings and reading
+	// series metadata from the index.
+	IndexTime atomic.Duration
+	// ChunkTime is the time spent opening chunk data from the chunk reader.
+	// Per-sample decoding during iteration is not included.
+	ChunkTime atomic.Duration
+}
+
+// Merge adds the counters of other to s.
+func (s *SelectStats) Merge(other *SelectStats) {
+	s.PostingsTouched.Add(other.PostingsTouched.Load())
+	s.SeriesExamined.Add(other.SeriesExamined.Load())
+	s.SeriesMatched.Add(other.SeriesMatched.Load())
+	s.ChunksTouched.Add(other.ChunksTouched.Load())
+	s.ChunksDecoded.Add(other.ChunksDecoded.Load())
+	s.SamplesDecoded.Add(other.SamplesDecoded.Load())
+	s.IndexTime.Add(other.IndexTime.Load())
+	s.ChunkTime.Add(other.ChunkTime.Load())
+}
+
+type selectStatsKey struct{}
+
+// ContextWithSelectStats returns a context that makes Select calls record
+// their work in s.
+func ContextWithSelectStats(ctx context.Context, s *SelectStats) context.Context {
+	return context.WithValue(ctx, selectStatsKey{}, s)
+}
+
+// SelectStatsFromContext returns the stats object attached with
+// ContextWithSelectStats, or nil if there is none.
+func SelectStatsFromContext(ctx context.Context) *SelectStats {
+	s, _ := ctx.Value(selectStatsKey{}).(*SelectStats)
+	return s
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/tombstones"
+)
+
+func TestSelectStats(t *testing.T) {
+	testData := []seriesSamples{
+		{
+			lset: map[string]string{"a": "a"},
+			chunks: [][]sample{
+				{{t: 1, f: 2}, {t: 2, f: 3}},
+				{{t: 5, f: 2}, {t: 6, f: 3}},
+			},
+		},
+		{
+			lset: map[string]string{"a": "a", "b": "b"},
+			chunks: [][]sample{
+				{{t: 1, f: 1}, {t: 2, f: 2}},
+			},
+		},
+		{
+			lset: map[string]string{"b": "b"},
+			chunks: [][]sample{
+				{{t: 1, f: 3}, {t: 2, f: 2}},
+			},
+		},
+	}
+	ir, cr, _, _ := createIdxChkReaders(t, testData)
+
+	for _, c := range []struct {
+		name               string
+		mint, maxt         int64
+		ms                 []*labels.Matcher
+		expPostingsTouched int64
+		expSeriesExamined  int64
+		expSeriesMatched   int64
+		expChunksTouched   int64
+		expChunksDecoded   int64
+		expSamplesDecoded  int64
+	}{
+		{
+			name:               "all series and chunks in range",
+			mint:               1,
+			maxt:               6,
+			ms:                 []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "a", "a")},
+			expPostingsTouched: 1,
+			expSeriesExamined:  2,
+			expSeriesMatched:   2,
+			expChunksTouched:   3,
+			expChunksDecoded:   3,
+			expSamplesDecoded:  6,
+		},
+		{
+			name:               "second chunk of first series outside range",
+			mint:               1,
+			maxt:               3,
+			ms:                 []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "a", "a")},
+			expPostingsTouched: 1,
+			expSeriesExamined:  2,
+			expSeriesMatched:   2,
+			expChunksTouched:   2,
+			expChunksDecoded:   2,
+			expSamplesDecoded:  4,
+		},
+		{
+			name:               "series examined but nothing in range",
+			mint:               10,
+			maxt:               20,
+			ms:                 []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "b", "b")},
+			expPostingsTouched: 1,
+			expSeriesExamined:  2,
+			expSeriesMatched:   0,
+			expChunksTouched:   0,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			q := blockQuerier{
+				blockBaseQuerier: &blockBaseQuerier{
+					index:      ir,
+					chunks:     cr,
+					tombstones: tombstones.NewMemTombstones(),
+
+					mint: c.mint,
+					maxt: c.maxt,
+				},
+			}
+			defer func() { require.NoError(t, q.Close()) }()
+
+			stats := &SelectStats{}
+			ctx := ContextWithSelectStats(context.Background(), stats)
+			require.Same(t, stats, SelectStatsFromContext(ctx))
+
+			res := q.Select(ctx, false, nil, c.ms...)
+			for res.Next() {
+				it := res.At().Iterator(nil)
+				for it.Next() != chunkenc.ValNone {
+				}
+				require.NoError(t, it.Err())
+			}
+			require.NoError(t, res.Err())
+
+			require.Equal(t, c.expPostingsTouched, stats.PostingsTouched.Load())
+			require.Equal(t, c.expSeriesExamined, stats.SeriesExamined.Load())
+			require.Equal(t, c.expSeriesMatched, stats.SeriesMatched.Load())
+			require.Equal(t, c.expChunksTouched, stats.ChunksTouched.Load())
+			require.Equal(t, c.expChunksDecoded, stats.ChunksDecoded.Load())
+			require.Equal(t, c.expSamplesDecoded, stats.SamplesDecoded.Load())
+		})
+	}
+}
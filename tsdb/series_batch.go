@@ -0,0 +1,57 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+)
+
+// SeriesBatchReader is an optional interface that an IndexReader can
+// implement to resolve many series references in one call, amortizing lock
+// acquisitions that per-ref Series calls would repeat.
+type SeriesBatchReader interface {
+	// SeriesBatch calls f once for every ref that resolves to a known series,
+	// in the order the refs are given. Refs that don't resolve are skipped.
+	// builder and *chks hold the series' labels and chunk metas for the
+	// duration of the callback only; both are reused between callbacks.
+	// A non-nil error from f stops the batch and is returned.
+	SeriesBatch(refs []storage.SeriesRef, builder *labels.ScratchBuilder, chks *[]chunks.Meta, f func(ref storage.SeriesRef, builder *labels.ScratchBuilder, chks []chunks.Meta) error) error
+}
+
+// SeriesBatch resolves refs against ir with the semantics of
+// SeriesBatchReader.SeriesBatch, falling back to per-ref Series calls when ir
+// doesn't implement SeriesBatchReader. chks must be non-nil.
+func SeriesBatch(ir IndexReader, refs []storage.SeriesRef, builder *labels.ScratchBuilder, chks *[]chunks.Meta, f func(ref storage.SeriesRef, builder *labels.ScratchBuilder, chks []chunks.Meta) error) error {
+	if br, ok := ir.(SeriesBatchReader); ok {
+		return br.SeriesBatch(refs, builder, chks, f)
+	}
+	for _, ref := range refs {
+		if err := ir.Series(ref, builder, chks); err != nil {
+			// Postings may be stale. Skip if no underlying series exists.
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("get series %d: %w", ref, err)
+		}
+		if err := f(ref, builder, *chks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/tsdb/index"
+	"github.com/prometheus/prometheus/util/compression"
+)
+
+type batchedSeries struct {
+	ref  storage.SeriesRef
+	lset labels.Labels
+	chks []chunks.Meta
+}
+
+func collectSeriesBatch(t *testing.T, ir IndexReader, refs []storage.SeriesRef) []batchedSeries {
+	t.Helper()
+
+	var (
+		builder labels.ScratchBuilder
+		chks    []chunks.Meta
+		got     []batchedSeries
+	)
+	err := SeriesBatch(ir, refs, &builder, &chks, func(ref storage.SeriesRef, builder *labels.ScratchBuilder, chks []chunks.Meta) error {
+		cp := make([]chunks.Meta, len(chks))
+		copy(cp, chks)
+		got = append(got, batchedSeries{ref: ref, lset: builder.Labels(), chks: cp})
+		return nil
+	})
+	require.NoError(t, err)
+	return got
+}
+
+func TestHeadIndexReader_SeriesBatch(t *testing.T) {
+	head, _ := newTestHead(t, 1000, compression.None, false)
+	t.Cleanup(func() { require.NoError(t, head.Close()) })
+
+	const numSeries = 100
+	app := head.Appender(context.Background())
+	for i := 0; i < numSeries; i++ {
+		_, err := app.Append(0, labels.FromStrings("foo", fmt.Sprintf("bar%03d", i)), 100, float64(i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, app.Commit())
+
+	ir, err := head.Index()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ir.Close()) })
+	require.Implements(t, (*SeriesBatchReader)(nil), ir)
+
+	p := ir.PostingsForAllLabelValues(context.Background(), "foo")
+	refs, err := index.ExpandPostings(p)
+	require.NoError(t, err)
+	require.Len(t, refs, numSeries)
+	// Mix in a stale ref, which must be skipped.
+	refs = append(refs[:50:50], append([]storage.SeriesRef{math.MaxUint32}, refs[50:]...)...)
+
+	got := collectSeriesBatch(t, ir, refs)
+	require.Len(t, got, numSeries)
+
+	// The batch must agree with per-ref Series lookups.
+	var (
+		builder labels.ScratchBuilder
+		chks    []chunks.Meta
+	)
+	for _, s := range got {
+		require.NoError(t, ir.Series(s.ref, &builder, &chks))
+		require.Equal(t, builder.Labels(), s.lset)
+		require.Equal(t, chks, s.chks)
+	}
+
+	// Errors from the callback abort the batch.
+	boom := errors.New("boom")
+	calls := 0
+	err = SeriesBatch(ir, refs, &builder, &chks, func(storage.SeriesRef, *labels.ScratchBuilder, []chunks.Meta) error {
+		calls++
+		return boom
+	})
+	require.ErrorIs(t, err, boom)
+	require.Equal(t, 1, calls)
+}
+
+func TestSeriesBatchFallback(t *testing.T) {
+	testData := []seriesSamples{
+		{
+			lset:   map[string]string{"a": "a"},
+			chunks: [][]sample{{{t: 1, f: 2}, {t: 2, f: 3}}},
+		},
+		{
+			lset:   map[string]string{"a": "a", "b": "b"},
+			chunks: [][]sample{{{t: 1, f: 1}, {t: 2, f: 2}}},
+		},
+	}
+	ir, _, _, _ := createIdxChkReaders(t, testData)
+	require.NotImplements(t, (*SeriesBatchReader)(nil), ir)
+
+	// Ref 0 is unknown and must be skipped.
+	got := collectSeriesBatch(t, ir, []storage.SeriesRef{0, 1, 2})
+	require.Len(t, got, 2)
+	require.Equal(t, labels.FromStrings("a", "a"), got[0].lset)
+	require.Equal(t, labels.FromStrings("a", "a", "b", "b"), got[1].lset)
+}
+
+func TestStripeSeries_getByIDs(t *testing.T) {
+	head, _ := newTestHead(t, 1000, compression.None, false)
+	t.Cleanup(func() { require.NoError(t, head.Close()) })
+
+	app := head.Appender(context.Background())
+	ref, err := app.Append(0, labels.FromStrings("foo", "bar"), 100, 1)
+	require.NoError(t, err)
+	require.NoError(t, app.Commit())
+
+	ids := []chunks.HeadSeriesRef{
+		chunks.HeadSeriesRef(ref) + 1000, // Unknown.
+		chunks.HeadSeriesRef(ref),
+		chunks.HeadSeriesRef(ref), // Duplicate ids are allowed.
+	}
+	series := head.series.getByIDs(ids, nil)
+	require.Len(t, series, len(ids))
+	require.Nil(t, series[0])
+	require.NotNil(t, series[1])
+	require.Same(t, series[1], series[2])
+
+	// The buffer is reused when it has enough capacity.
+	buf := make([]*memSeries, 0, len(ids))
+	series = head.series.getByIDs(ids, buf)
+	require.Same(t, &buf[:1][0], &series[0])
+}
@@ -144,6 +144,7 @@ var (
 	MixedExponentialCustomHistogramsWarning    = fmt.Errorf("%w: vector contains a mix of histograms with exponential and custom buckets schemas for metric name", PromQLWarning)
 	IncompatibleCustomBucketsHistogramsWarning = fmt.Errorf("%w: vector contains histograms with incompatible custom buckets for metric name", PromQLWarning)
 	IncompatibleBucketLayoutInBinOpWarning     = fmt.Errorf("%w: incompatible bucket layout encountered for binary operator", PromQLWarning)
+	InvalidTimezoneWarning                     = fmt.Errorf("%w: unknown timezone, using UTC instead:", PromQLWarning)
 
 	PossibleNonCounterInfo                  = fmt.Errorf("%w: metric might not be a counter, name does not end in _total/_sum/_count/_bucket:", PromQLInfo)
 	PossibleNonCounterLabelInfo             = fmt.Errorf("%w: metric might not be a counter, __type__ label is not set to %q or %q", PromQLInfo, model.MetricTypeCounter, model.MetricTypeHistogram)
@@ -154,6 +155,7 @@ var (
 	NativeHistogramQuantileNaNResultInfo    = fmt.Errorf("%w: input to histogram_quantile has NaN observations, result is NaN", PromQLInfo)
 	NativeHistogramQuantileNaNSkewInfo      = fmt.Errorf("%w: input to histogram_quantile has NaN observations, result is skewed higher", PromQLInfo)
 	NativeHistogramFractionNaNsInfo         = fmt.Errorf("%w: input to histogram_fraction has NaN observations, which are excluded from all fractions", PromQLInfo)
+	RangeQueryStepAlignedInfo               = fmt.Errorf("%w: range query start and end were aligned down to multiples of the step", PromQLInfo)
 	HistogramCounterResetCollisionWarning   = fmt.Errorf("%w: conflicting counter resets during histogram", PromQLWarning)
 )
 
@@ -336,6 +338,15 @@ func NewIncompatibleBucketLayoutInBinOpWarning(operator string, pos posrange.Pos
 	}
 }
 
+// NewInvalidTimezoneWarning is used when a calendar function is called with a
+// timezone that cannot be loaded, in which case the result is computed in UTC.
+func NewInvalidTimezoneWarning(tz string, pos posrange.PositionRange) error {
+	return annoErr{
+		PositionRange: pos,
+		Err:           fmt.Errorf("%w %q", InvalidTimezoneWarning, tz),
+	}
+}
+
 func NewNativeHistogramQuantileNaNResultInfo(metricName string, pos posrange.PositionRange) error {
 	return annoErr{
 		PositionRange: pos,
@@ -357,6 +368,14 @@ func NewNativeHistogramFractionNaNsInfo(metricName string, pos posrange.Position
 	}
 }
 
+// NewRangeQueryStepAlignedInfo is used when the engine aligned a range
+// query's start and end timestamps to multiples of the step.
+func NewRangeQueryStepAlignedInfo() error {
+	return annoErr{
+		Err: RangeQueryStepAlignedInfo,
+	}
+}
+
 type HistogramOperation string
 
 const (
@@ -46,3 +46,8 @@ func (g *Gate) Done() {
 		panic("gate.Done: more operations done than started")
 	}
 }
+
+// Capacity returns the number of concurrent operations the gate allows.
+func (g *Gate) Capacity() int {
+	return cap(g.ch)
+}